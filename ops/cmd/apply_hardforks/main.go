@@ -55,11 +55,14 @@ func processSuperchainDir(wd string, superchain config.Superchain) error {
 	superchainCfgPath := paths.SuperchainConfig(wd, superchain)
 
 	var superchainCfg config.SuperchainDefinition
-	if err := paths.ReadTOMLFile(superchainCfgPath, &superchainCfg); err != nil {
+	if err := paths.ReadTOMLFileStrict(superchainCfgPath, &superchainCfg); err != nil {
 		return fmt.Errorf("error reading superchain config: %w", err)
 	}
+	if err := superchainCfg.Hardforks.ValidateOrder(); err != nil {
+		return fmt.Errorf("invalid hardforks in %s: %w", superchainCfgPath, err)
+	}
 
-	cfgs, err := manage.CollectChainConfigs(paths.SuperchainDir(wd, superchain))
+	cfgs, err := manage.CollectChainConfigsStrict(paths.SuperchainDir(wd, superchain))
 	if err != nil {
 		return fmt.Errorf("error collecting chain configs: %w", err)
 	}
@@ -75,6 +78,9 @@ func processSuperchainDir(wd string, superchain config.Superchain) error {
 		); err != nil {
 			return fmt.Errorf("error copying hardforks: %w", err)
 		}
+		if err := chainCfg.Hardforks.ValidateOrder(); err != nil {
+			return fmt.Errorf("invalid hardforks in %s: %w", cfg.Filepath, err)
+		}
 
 		realCfgData, err := toml.Marshal(chainCfg)
 		if err != nil {
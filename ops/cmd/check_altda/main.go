@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/lmittmann/w3"
+	"github.com/urfave/cli/v2"
+)
+
+var L1RPCURLsFlag = &cli.StringSliceFlag{
+	Name:    "l1-rpc-urls",
+	Usage:   "comma-separated list of L1 RPC URLs used to check challenge contracts onchain (skipped if not provided)",
+	EnvVars: []string{"L1_RPC_URLS"},
+}
+
+var (
+	SourceFlag = &cli.StringFlag{
+		Name:  "source",
+		Usage: "registry to check instead of the working tree: a checkout directory, git:<rev>, or a .tar, .tar.gz or .tgz archive",
+	}
+	OverlayFlag = &cli.StringSliceFlag{
+		Name:  "overlay",
+		Usage: "directory with the layout of the registry to merge over it, can be repeated",
+	}
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "check-altda",
+		Usage: "checks that every chain's alt-DA config is consistent, and optionally matches its challenge contract onchain",
+		Flags: []cli.Flag{
+			L1RPCURLsFlag,
+			SourceFlag,
+			OverlayFlag,
+		},
+		Action: CheckAltDACLI,
+	}
+	if err := app.Run(os.Args); err != nil {
+		output.WriteNotOK("%v", err)
+		os.Exit(1)
+	}
+}
+
+func CheckAltDACLI(cliCtx *cli.Context) error {
+	lgr := log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stderr, log.LevelInfo, false))
+	wd, err := paths.FindRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	src, err := paths.OpenSource(wd, cliCtx.String(SourceFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open source: %w", err)
+	}
+
+	overlays, err := paths.OpenOverlays(cliCtx.StringSlice(OverlayFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open overlays: %w", err)
+	}
+
+	reg, err := manage.LoadRegistryOverlay(src, overlays, true)
+	if err != nil {
+		return fmt.Errorf("failed to load registry: %w", err)
+	}
+	cfgs := reg.Chains()
+
+	var errs []error
+	bySuperchain := make(map[config.Superchain][]manage.DiskChainConfig)
+	for _, cfg := range cfgs {
+		if err := manage.ValidateAltDA(cfg.Config); err != nil {
+			errs = append(errs, fmt.Errorf("%s/%s: %w", cfg.Superchain, cfg.ShortName, err))
+		}
+		if cfg.Config.AltDA != nil && cfg.Config.AltDA.DaCommitmentType == config.KeccakCommitmentType {
+			bySuperchain[cfg.Superchain] = append(bySuperchain[cfg.Superchain], cfg)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	output.WriteOK("alt-DA configs of %d chains are consistent", len(cfgs))
+
+	l1RpcUrls := cliCtx.StringSlice(L1RPCURLsFlag.Name)
+	if len(l1RpcUrls) == 0 {
+		output.WriteWarn("no L1 RPC URLs provided, skipping onchain checks")
+		return nil
+	}
+
+	for superchain, superchainCfgs := range bySuperchain {
+		superchainDef, ok := reg.SuperchainDefinition(superchain)
+		if !ok {
+			return fmt.Errorf("superchain %s has no superchain config", superchain)
+		}
+		l1RpcUrl, err := config.FindValidL1URL(cliCtx.Context, lgr, l1RpcUrls, superchainDef.L1.ChainID)
+		if err != nil {
+			return fmt.Errorf("failed to find L1 RPC URL for superchain %s: %w", superchain, err)
+		}
+
+		client, err := w3.Dial(l1RpcUrl)
+		if err != nil {
+			return fmt.Errorf("failed to connect to L1 RPC for superchain %s: %w", superchain, err)
+		}
+		err = manage.CheckAltDAOnchain(cliCtx.Context, client, superchainCfgs)
+		client.Close()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		output.WriteOK("alt-DA challenge contracts in superchain %s match onchain", superchain)
+	}
+	return errors.Join(errs...)
+}
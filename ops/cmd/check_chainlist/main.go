@@ -8,22 +8,41 @@ import (
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/urfave/cli/v2"
 )
 
+var SourceFlag = &cli.StringFlag{
+	Name:  "source",
+	Usage: "registry to check instead of the working tree: a checkout directory, git:<rev>, or a .tar, .tar.gz or .tgz archive",
+}
+
 func main() {
-	if err := mainErr(); err != nil {
+	app := &cli.App{
+		Name:  "check-chainlist",
+		Usage: "checks that every staged chain's chain ID, short name and name match the global chain list",
+		Flags: []cli.Flag{
+			SourceFlag,
+		},
+		Action: CheckChainlistCLI,
+	}
+	if err := app.Run(os.Args); err != nil {
 		output.WriteNotOK("%v\n", err)
 		os.Exit(1)
 	}
 }
 
-func mainErr() error {
+func CheckChainlistCLI(cliCtx *cli.Context) error {
 	wd, err := paths.FindRepoRoot()
 	if err != nil {
 		return fmt.Errorf("failed to get working directory: %w", err)
 	}
 
-	stagedChainCfgs, err := manage.StagedChainConfigs(wd)
+	src, err := paths.OpenSource(wd, cliCtx.String(SourceFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open source: %w", err)
+	}
+
+	stagedChainCfgs, err := manage.StagedChainConfigsFS(src)
 	if errors.Is(err, manage.ErrNoStagedConfig) {
 		output.WriteOK("no staged chain config found, exiting")
 		return nil
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
@@ -12,10 +11,25 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+var (
+	SourceFlag = &cli.StringFlag{
+		Name:  "source",
+		Usage: "registry to check instead of the working tree: a checkout directory, git:<rev>, or a .tar, .tar.gz or .tgz archive",
+	}
+	OverlayFlag = &cli.StringSliceFlag{
+		Name:  "overlay",
+		Usage: "directory with the layout of the registry to merge over it, can be repeated",
+	}
+)
+
 func main() {
 	app := &cli.App{
-		Name:   "check-depsets",
-		Usage:  "checks that all interop depsets in the chain configs are valid",
+		Name:  "check-depsets",
+		Usage: "checks that all interop depsets in the chain configs are valid",
+		Flags: []cli.Flag{
+			SourceFlag,
+			OverlayFlag,
+		},
 		Action: CheckDepsetsCLI,
 	}
 	if err := app.Run(os.Args); err != nil {
@@ -31,18 +45,30 @@ func CheckDepsetsCLI(cliCtx *cli.Context) error {
 		return fmt.Errorf("failed to get working directory: %w", err)
 	}
 
-	superchainCfgsDir := paths.SuperchainConfigsDir(wd)
-	cfgs, err := manage.CollectChainConfigs(superchainCfgsDir)
+	src, err := paths.OpenSource(wd, cliCtx.String(SourceFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open source: %w", err)
+	}
+
+	overlays, err := paths.OpenOverlays(cliCtx.StringSlice(OverlayFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open overlays: %w", err)
+	}
+
+	reg, err := manage.LoadRegistryOverlay(src, overlays, true)
 	if err != nil {
-		return fmt.Errorf("failed to collect chain configs: %w", err)
+		return fmt.Errorf("failed to load registry: %w", err)
 	}
 
-	var addrs config.AddressesJSON
-	if err := paths.ReadJSONFile(paths.AddressesFile(wd), &addrs); err != nil {
-		return fmt.Errorf("failed to read addresses.json file: %w", err)
+	graph, err := manage.NewDependencyGraph(reg.Chains())
+	if err != nil {
+		return fmt.Errorf("failed to build dependency graph: %w", err)
+	}
+	if err := graph.Validate(); err != nil {
+		return fmt.Errorf("failed to validate dependency graph: %w", err)
 	}
 
-	checker := manage.NewDepsetChecker(lgr, cfgs, addrs)
+	checker := manage.NewDepsetChecker(lgr, reg.Chains(), reg.Addresses())
 	if err := checker.Check(); err != nil {
 		return fmt.Errorf("failed to validate depsets: %w", err)
 	}
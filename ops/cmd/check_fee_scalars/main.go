@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	SourceFlag = &cli.StringFlag{
+		Name:  "source",
+		Usage: "registry to check instead of the working tree: a checkout directory, git:<rev>, or a .tar, .tar.gz or .tgz archive",
+	}
+	OverlayFlag = &cli.StringSliceFlag{
+		Name:  "overlay",
+		Usage: "directory with the layout of the registry to merge over it, can be repeated",
+	}
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "check-fee-scalars",
+		Usage: "checks that every chain's genesis fee scalars decode and are within the standard ranges",
+		Flags: []cli.Flag{
+			SourceFlag,
+			OverlayFlag,
+		},
+		Action: CheckFeeScalarsCLI,
+	}
+	if err := app.Run(os.Args); err != nil {
+		output.WriteNotOK("%v", err)
+		os.Exit(1)
+	}
+}
+
+func CheckFeeScalarsCLI(cliCtx *cli.Context) error {
+	wd, err := paths.FindRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	src, err := paths.OpenSource(wd, cliCtx.String(SourceFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open source: %w", err)
+	}
+
+	overlays, err := paths.OpenOverlays(cliCtx.StringSlice(OverlayFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open overlays: %w", err)
+	}
+
+	reg, err := manage.LoadRegistryOverlay(src, overlays, true)
+	if err != nil {
+		return fmt.Errorf("failed to load registry: %w", err)
+	}
+
+	var errs []error
+	var checked int
+	for _, cfg := range reg.Chains() {
+		params, ok := manage.StandardConfigParams(cfg.Superchain)
+		if !ok {
+			continue
+		}
+		if err := manage.ValidateFeeScalars(cfg.Config, params.GasPriceOracle.Ecotone); err != nil {
+			errs = append(errs, fmt.Errorf("%s/%s: %w", cfg.Superchain, cfg.ShortName, err))
+		}
+		checked++
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	output.WriteOK("fee scalars of %d chains are valid", checked)
+	return nil
+}
@@ -7,43 +7,66 @@ import (
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	SourceFlag = &cli.StringFlag{
+		Name:  "source",
+		Usage: "registry to check instead of the working tree: a checkout directory, git:<rev>, or a .tar, .tar.gz or .tgz archive",
+	}
+	OverlayFlag = &cli.StringSliceFlag{
+		Name:  "overlay",
+		Usage: "directory with the layout of the registry to merge over it, can be repeated",
+	}
 )
 
 func main() {
-	if err := mainErr(); err != nil {
+	app := &cli.App{
+		Name:  "check-genesis-integrity",
+		Usage: "checks that the genesis of every chain matches its chain config",
+		Flags: []cli.Flag{
+			SourceFlag,
+			OverlayFlag,
+		},
+		Action: CheckGenesisIntegrityCLI,
+	}
+	if err := app.Run(os.Args); err != nil {
 		output.WriteStderr("%v\n", err)
 		os.Exit(1)
 	}
 }
 
-func mainErr() error {
+func CheckGenesisIntegrityCLI(cliCtx *cli.Context) error {
 	wd, err := paths.FindRepoRoot()
 	if err != nil {
 		return fmt.Errorf("failed to get working directory: %w", err)
 	}
 
-	superchains, err := paths.Superchains(wd)
+	src, err := paths.OpenSource(wd, cliCtx.String(SourceFlag.Name))
 	if err != nil {
-		return fmt.Errorf("error getting superchains: %w", err)
+		return fmt.Errorf("failed to open source: %w", err)
 	}
 
-	var integrityCheckFailed bool
-	for _, superchain := range superchains {
-		cfgs, err := manage.CollectChainConfigs(paths.SuperchainDir(wd, superchain))
-		if err != nil {
-			return fmt.Errorf("error collecting chain configs: %w", err)
-		}
+	overlays, err := paths.OpenOverlays(cliCtx.StringSlice(OverlayFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open overlays: %w", err)
+	}
+
+	reg, err := manage.LoadRegistryOverlay(src, overlays, true)
+	if err != nil {
+		return fmt.Errorf("error loading registry: %w", err)
+	}
 
-		for _, cfg := range cfgs {
-			if (cfg.ShortName == "op" && superchain == "mainnet") ||
-				(cfg.ShortName == "boba" && superchain == "mainnet") ||
-				(cfg.ShortName == "boba" && superchain == "sepolia") ||
-				(cfg.ShortName == "celo" && superchain == "mainnet") {
+	var integrityCheckFailed bool
+	for _, superchain := range reg.Superchains() {
+		for _, cfg := range reg.ChainsBySuperchain(superchain) {
+			if manage.IsLegacyGenesis(superchain, cfg.ShortName) {
 				output.WriteWarn("skipping %s %s - chain was migrated from a legacy state", cfg.ShortName, superchain)
 				continue
 			}
 
-			genesis, err := manage.ReadSuperchainGenesis(wd, superchain, cfg.ShortName)
+			genesis, err := manage.ReadSuperchainGenesisFS(reg.FS(), superchain, cfg.ShortName)
 			if err != nil {
 				output.WriteNotOK("error decompressing genesis for %s/%s: %v", superchain, cfg.ShortName, err)
 				integrityCheckFailed = true
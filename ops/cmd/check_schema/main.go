@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	WriteSchemasFlag = &cli.StringFlag{
+		Name:      "write-schemas",
+		Usage:     "directory to write the generated JSON Schemas to before validating",
+		TakesFile: true,
+	}
+	SourceFlag = &cli.StringFlag{
+		Name:  "source",
+		Usage: "registry to check instead of the working tree: a checkout directory, git:<rev>, or a .tar, .tar.gz or .tgz archive",
+	}
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "check-schema",
+		Usage: "validates every config TOML in superchain/configs and .staging against the generated JSON Schemas",
+		Flags: []cli.Flag{
+			WriteSchemasFlag,
+			SourceFlag,
+		},
+		Action: CheckSchemaCLI,
+	}
+	if err := app.Run(os.Args); err != nil {
+		output.WriteNotOK("%v", err)
+		os.Exit(1)
+	}
+}
+
+func CheckSchemaCLI(cliCtx *cli.Context) error {
+	wd, err := paths.FindRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	src, err := paths.OpenSource(wd, cliCtx.String(SourceFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open source: %w", err)
+	}
+
+	if schemaDir := cliCtx.String(WriteSchemasFlag.Name); schemaDir != "" {
+		if err := manage.WriteConfigSchemas(schemaDir); err != nil {
+			return fmt.Errorf("failed to write schemas: %w", err)
+		}
+		output.WriteOK("wrote schemas to %s", schemaDir)
+	}
+
+	schemaErrs, err := manage.ValidateConfigSchemas(src)
+	if err != nil {
+		return fmt.Errorf("failed to validate configs: %w", err)
+	}
+	for _, schemaErr := range schemaErrs {
+		output.WriteNotOK("%v", schemaErr)
+	}
+	if len(schemaErrs) > 0 {
+		return fmt.Errorf("found %d schema errors", len(schemaErrs))
+	}
+
+	output.WriteOK("all configs match their schemas")
+	return nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/urfave/cli/v2"
+)
+
+var JSONFlag = &cli.BoolFlag{
+	Name:  "json",
+	Usage: "print the results as JSON instead of a table",
+}
+
+var (
+	SourceFlag = &cli.StringFlag{
+		Name:  "source",
+		Usage: "registry to check instead of the working tree: a checkout directory, git:<rev>, or a .tar, .tar.gz or .tgz archive",
+	}
+	OverlayFlag = &cli.StringSliceFlag{
+		Name:  "overlay",
+		Usage: "directory with the layout of the registry to merge over it, can be repeated",
+	}
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "check-standard-params",
+		Usage: "checks every chain's rollup and system config params against its superchain's standard params",
+		Flags: []cli.Flag{
+			JSONFlag,
+			SourceFlag,
+			OverlayFlag,
+		},
+		Action: CheckStandardParamsCLI,
+	}
+	if err := app.Run(os.Args); err != nil {
+		output.WriteNotOK("%v", err)
+		os.Exit(1)
+	}
+}
+
+func CheckStandardParamsCLI(cliCtx *cli.Context) error {
+	wd, err := paths.FindRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	src, err := paths.OpenSource(wd, cliCtx.String(SourceFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open source: %w", err)
+	}
+
+	overlays, err := paths.OpenOverlays(cliCtx.StringSlice(OverlayFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open overlays: %w", err)
+	}
+
+	reg, err := manage.LoadRegistryOverlay(src, overlays, true)
+	if err != nil {
+		return fmt.Errorf("failed to load registry: %w", err)
+	}
+
+	allowlist, err := manage.ReadStandardParamsAllowlist(reg.FS())
+	if err != nil {
+		return fmt.Errorf("failed to read standard params allowlist: %w", err)
+	}
+
+	cfgs := reg.Chains()
+	slices.SortFunc(cfgs, func(a, b manage.DiskChainConfig) int {
+		return cmp.Or(cmp.Compare(a.Superchain, b.Superchain), cmp.Compare(a.ShortName, b.ShortName))
+	})
+
+	results, err := manage.CheckStandardParams(reg.FS(), cfgs, allowlist)
+	if err != nil {
+		return err
+	}
+
+	if cliCtx.Bool(JSONFlag.Name) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return fmt.Errorf("failed to write results: %w", err)
+		}
+	} else if err := writeTable(results); err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+
+	var failed int
+	for _, res := range results {
+		for _, check := range res.Checks {
+			if check.Allowed && check.Standard() {
+				output.WriteWarn("%s/%s is allowed to deviate on %s, but is standard", res.Superchain, res.ShortName, check.Param)
+			}
+		}
+		if !res.Passed() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d chains deviate from standard params", failed, len(results))
+	}
+	output.WriteOK("all %d chains are within standard params or allowlisted", len(results))
+	return nil
+}
+
+func writeTable(results []manage.StandardParamsResult) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHAIN\tCHAIN ID\tSEQ WINDOW SIZE\tBLOCK TIME\tGAS LIMIT\tRESULT")
+	for _, res := range results {
+		fmt.Fprintf(w, "%s/%s\t%d", res.Superchain, res.ShortName, res.ChainID)
+		for _, check := range res.Checks {
+			fmt.Fprintf(w, "\t%s", formatCheck(check))
+		}
+		result := "PASS"
+		if !res.Passed() {
+			result = "FAIL"
+		}
+		fmt.Fprintf(w, "\t%s\n", result)
+	}
+	return w.Flush()
+}
+
+func formatCheck(check manage.ParamCheck) string {
+	switch {
+	case check.Standard():
+		return fmt.Sprintf("%d", check.Value)
+	case check.Allowed:
+		return fmt.Sprintf("%d (allowed)", check.Value)
+	default:
+		return fmt.Sprintf("%d (want %d-%d)", check.Value, check.Range[0], check.Range[1])
+	}
+}
@@ -2,9 +2,11 @@ package main
 
 import (
 	"fmt"
+	iofs "io/fs"
 	"os"
 
-	"github.com/ethereum-optimism/optimism/op-fetcher/pkg/fetcher/fetch/script"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/l1block"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
@@ -30,6 +32,30 @@ var (
 		Name:  "prune-removed",
 		Usage: "remove generated entries for chain configs that no longer exist without fetching on-chain data",
 	}
+	OverlayFlag = &cli.StringSliceFlag{
+		Name:  "overlay",
+		Usage: "directory with the layout of the registry to merge over it, can be repeated",
+	}
+	OutputDirFlag = &cli.StringFlag{
+		Name:  "output-dir",
+		Usage: "directory to write the generated files to (defaults to the last overlay if any are given, and the registry otherwise)",
+	}
+	FromSnapshotsFlag = &cli.BoolFlag{
+		Name:  "from-snapshots",
+		Usage: "use the onchain snapshots written by refresh-onchain to superchain/extra/onchain instead of fetching onchain data, so no L1 RPC URLs are needed",
+	}
+	L1BlocksFlag = &cli.StringSliceFlag{
+		Name:  "l1-blocks",
+		Usage: "comma-separated list of superchain=number or superchain=hash L1 blocks to read onchain data at and record in l1-blocks.json (defaults to the latest block of each superchain at the start of the run, which is not recorded)",
+	}
+	CheckFlag = &cli.BoolFlag{
+		Name:  "check",
+		Usage: "generate the files in memory and fail if they differ from the ones in the output directory, without writing anything",
+	}
+	SourceFlag = &cli.StringFlag{
+		Name:  "source",
+		Usage: "registry to check instead of the working tree, only with --check: a checkout directory, git:<rev>, or a .tar, .tar.gz or .tgz archive",
+	}
 )
 
 func main() {
@@ -41,6 +67,12 @@ func main() {
 			ChainIDFlag,
 			SuperchainsFlag,
 			PruneRemovedFlag,
+			OverlayFlag,
+			OutputDirFlag,
+			FromSnapshotsFlag,
+			L1BlocksFlag,
+			CheckFlag,
+			SourceFlag,
 		},
 		Action: CodegenCLI,
 	}
@@ -55,6 +87,8 @@ func CodegenCLI(cliCtx *cli.Context) error {
 	chainIds := cliCtx.Uint64Slice("chain-ids")
 	superchainsRaw := cliCtx.StringSlice("superchains")
 	pruneRemoved := cliCtx.Bool("prune-removed")
+	check := cliCtx.Bool(CheckFlag.Name)
+	fromSnapshots := cliCtx.Bool(FromSnapshotsFlag.Name)
 	// Filter out empty strings from superchains
 	var superchains []string
 	for _, sc := range superchainsRaw {
@@ -69,37 +103,114 @@ func CodegenCLI(cliCtx *cli.Context) error {
 	if pruneRemoved && (len(chainIds) > 0 || len(superchains) > 0) {
 		return fmt.Errorf("cannot provide chain-ids or superchains with prune-removed")
 	}
+	if pruneRemoved && fromSnapshots {
+		return fmt.Errorf("cannot provide both prune-removed and from-snapshots flags")
+	}
+	l1Blocks, err := l1block.ParseSpecs(cliCtx.StringSlice(L1BlocksFlag.Name))
+	if err != nil {
+		return err
+	}
+	if len(l1Blocks) > 0 && (pruneRemoved || fromSnapshots) {
+		return fmt.Errorf("cannot provide l1-blocks with prune-removed or from-snapshots")
+	}
+	source := cliCtx.String(SourceFlag.Name)
+	if source != "" && !check {
+		return fmt.Errorf("cannot provide source without check, since generated files are only written to the working tree")
+	}
 
 	lgr := log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stderr, log.LevelInfo, false))
 	wd, err := paths.FindRepoRoot()
 	if err != nil {
 		return fmt.Errorf("failed to get working directory: %w", err)
 	}
+	src, err := paths.OpenSource(wd, source)
+	if err != nil {
+		return fmt.Errorf("failed to open source: %w", err)
+	}
+	overlays, err := paths.OpenOverlays(cliCtx.StringSlice(OverlayFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open overlays: %w", err)
+	}
+	reg, err := manage.LoadRegistryOverlay(src, overlays, false)
+	if err != nil {
+		return fmt.Errorf("failed to load registry: %w", err)
+	}
+
+	// Generated files include the chains of the overlays, so they are written
+	// next to them rather than into the public registry.
+	var opts []manage.CodegenSyncerOption
+	outputDir := cliCtx.String(OutputDirFlag.Name)
+	if outputDir == "" && len(overlays) > 0 {
+		last, ok := overlays[len(overlays)-1].(*fs.DirFS)
+		if !ok {
+			return fmt.Errorf("last overlay is not a directory, provide --%s", OutputDirFlag.Name)
+		}
+		outputDir = last.Dir()
+	}
+	committed := reg.FS()
+	if outputDir != "" {
+		committed = fs.NewDirFS(outputDir)
+	}
+	generated := fs.NewMemFS()
+	switch {
+	case check:
+		opts = append(opts, manage.WithOutputFS(generated))
+	case outputDir != "":
+		opts = append(opts, manage.WithOutputDirectory(outputDir))
+	}
+
 	if pruneRemoved {
-		if err := manage.PruneRemovedChains(lgr, wd); err != nil {
+		if err := manage.PruneRemovedChains(lgr, reg, opts...); err != nil {
 			return fmt.Errorf("error pruning removed chains: %w", err)
 		}
+		if check {
+			return checkGenerated(committed, generated)
+		}
 		return nil
 	}
-	if len(l1RpcUrls) == 0 {
-		return fmt.Errorf("l1-rpc-urls is required unless prune-removed is set")
+	if len(l1RpcUrls) == 0 && !fromSnapshots {
+		return fmt.Errorf("l1-rpc-urls is required unless prune-removed or from-snapshots is set")
 	}
-	if err := manage.ValidateRequiredSuperchains(wd); err != nil {
+	if err := manage.ValidateRequiredSuperchains(reg.FS()); err != nil {
 		return err
 	}
 
-	var onchainCfgs map[uint64]script.ChainConfig
-	ctx := cliCtx.Context
-	onchainCfgs, err = manage.FetchChains(ctx, lgr, wd, l1RpcUrls, chainIds, superchains)
-	if err != nil {
-		return fmt.Errorf("error fetching onchain configs: %w", err)
+	var snapshots map[uint64]*manage.OnchainSnapshot
+	if fromSnapshots {
+		snapshots, err = manage.ReadOnchainSnapshots(reg, chainIds, superchains)
+		if err != nil {
+			return fmt.Errorf("error reading onchain snapshots: %w", err)
+		}
+	} else {
+		snapshots, err = manage.FetchChains(cliCtx.Context, lgr, reg, l1RpcUrls, chainIds, superchains, l1Blocks)
+		if err != nil {
+			return fmt.Errorf("error fetching onchain configs: %w", err)
+		}
+		opts = append(opts, manage.WithL1Blocks(manage.PinnedL1Blocks(reg, snapshots, l1Blocks)))
 	}
-	syncer, err := manage.NewCodegenSyncer(lgr, wd, onchainCfgs)
+	syncer, err := manage.NewSnapshotCodegenSyncer(lgr, reg, snapshots, opts...)
 	if err != nil {
 		return fmt.Errorf("error creating codegen syncer: %w", err)
 	}
 	if err := syncer.SyncAll(); err != nil {
 		return fmt.Errorf("error syncing codegen: %w", err)
 	}
+	if check {
+		return checkGenerated(committed, generated)
+	}
+	return nil
+}
+
+func checkGenerated(committed iofs.FS, generated *fs.MemFS) error {
+	diff, err := manage.DiffCodegenFiles(committed, generated)
+	if err != nil {
+		return fmt.Errorf("error diffing generated files: %w", err)
+	}
+	if err := diff.WriteText(os.Stdout); err != nil {
+		return fmt.Errorf("error writing diff: %w", err)
+	}
+	if diff.Stale() {
+		return fmt.Errorf("%d generated files are stale, rerun codegen without --check to update them", len(diff.Files))
+	}
 	return nil
 }
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	ChainIDFlag = &cli.Uint64Flag{
+		Name:     "chain-id",
+		Usage:    "L2 chain ID of the chain to export",
+		Required: true,
+	}
+	OutputFlag = &cli.StringFlag{
+		Name:      "output",
+		Usage:     "file to write genesis.json to (defaults to stdout)",
+		TakesFile: true,
+	}
+	CheckHashFlag = &cli.BoolFlag{
+		Name:  "check-hash",
+		Usage: "fail if the genesis block hash does not match the chain config's L2 genesis hash",
+	}
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "export-genesis",
+		Usage: "exports the op-geth genesis.json for a chain in the registry",
+		Flags: []cli.Flag{
+			ChainIDFlag,
+			OutputFlag,
+			CheckHashFlag,
+		},
+		Action: ExportGenesisCLI,
+	}
+	if err := app.Run(os.Args); err != nil {
+		output.WriteStderr("%v", err)
+		os.Exit(1)
+	}
+}
+
+func ExportGenesisCLI(cliCtx *cli.Context) error {
+	wd, err := paths.FindRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	chainID := cliCtx.Uint64(ChainIDFlag.Name)
+	genesis, err := manage.ReadOpGethGenesis(wd, chainID)
+	if err != nil {
+		return fmt.Errorf("failed to build genesis for chain %d: %w", chainID, err)
+	}
+
+	if cliCtx.Bool(CheckHashFlag.Name) {
+		cfg, err := manage.FindChainConfig(wd, chainID)
+		if err != nil {
+			return err
+		}
+		if err := manage.CheckGenesisHash(cfg.Config, genesis); err != nil {
+			return fmt.Errorf("genesis hash check failed for chain %d: %w", chainID, err)
+		}
+		output.WriteOK("genesis hash matches %s", cfg.Config.Genesis.L2.Hash)
+	}
+
+	data, err := json.MarshalIndent(genesis, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal genesis: %w", err)
+	}
+	data = append(data, '\n')
+
+	outPath := cliCtx.String(OutputFlag.Name)
+	if outPath == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	if err := fs.AtomicWrite(outPath, 0o644, data); err != nil {
+		return fmt.Errorf("failed to write genesis: %w", err)
+	}
+	output.WriteOK("wrote genesis for chain %d to %s", chainID, outPath)
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	ChainIDFlag = &cli.Uint64Flag{
+		Name:     "chain-id",
+		Usage:    "L2 chain ID of the chain to export",
+		Required: true,
+	}
+	OutputFlag = &cli.StringFlag{
+		Name:      "output",
+		Usage:     "file to write rollup.json to (defaults to stdout)",
+		TakesFile: true,
+	}
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "export-rollup-config",
+		Usage: "exports the op-node rollup.json for a chain in the registry",
+		Flags: []cli.Flag{
+			ChainIDFlag,
+			OutputFlag,
+		},
+		Action: ExportRollupConfigCLI,
+	}
+	if err := app.Run(os.Args); err != nil {
+		output.WriteStderr("%v", err)
+		os.Exit(1)
+	}
+}
+
+func ExportRollupConfigCLI(cliCtx *cli.Context) error {
+	wd, err := paths.FindRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	chainID := cliCtx.Uint64(ChainIDFlag.Name)
+	rollupCfg, err := manage.ReadRollupConfig(wd, chainID)
+	if err != nil {
+		return fmt.Errorf("failed to build rollup config for chain %d: %w", chainID, err)
+	}
+
+	data, err := json.MarshalIndent(rollupCfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal rollup config: %w", err)
+	}
+	data = append(data, '\n')
+
+	outPath := cliCtx.String(OutputFlag.Name)
+	if outPath == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	if err := fs.AtomicWrite(outPath, 0o644, data); err != nil {
+		return fmt.Errorf("failed to write rollup config: %w", err)
+	}
+	output.WriteOK("wrote rollup config for chain %d to %s", chainID, outPath)
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/urfave/cli/v2"
+)
+
+var CheckFlag = &cli.BoolFlag{
+	Name:  "check",
+	Usage: "print every non-checksummed address as JSON and exit non-zero instead of fixing them",
+}
+
+func main() {
+	app := &cli.App{
+		Name:  "fix-checksums",
+		Usage: "rewrites non-checksummed addresses in staged and chain configs to their EIP-55 form",
+		Flags: []cli.Flag{
+			CheckFlag,
+		},
+		Action: FixChecksumsCLI,
+	}
+	if err := app.Run(os.Args); err != nil {
+		output.WriteNotOK("%v", err)
+		os.Exit(1)
+	}
+}
+
+func FixChecksumsCLI(cliCtx *cli.Context) error {
+	wd, err := paths.FindRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	check := cliCtx.Bool(CheckFlag.Name)
+	fixes, err := manage.FixChecksumsInFiles(wd, !check)
+	if err != nil {
+		return err
+	}
+
+	if check {
+		if fixes == nil {
+			fixes = []manage.ChecksumFix{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(fixes); err != nil {
+			return fmt.Errorf("failed to write checksum errors: %w", err)
+		}
+		if len(fixes) > 0 {
+			return fmt.Errorf("found %d non-checksummed addresses", len(fixes))
+		}
+		return nil
+	}
+
+	for _, fix := range fixes {
+		output.WriteOK("%s", fix)
+	}
+	if len(fixes) == 0 {
+		output.WriteOK("all addresses are checksummed")
+	} else {
+		output.WriteOK("fixed %d addresses", len(fixes))
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	FormatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: "output format: json, ics or markdown",
+		Value: "markdown",
+	}
+	TimestampFlag = &cli.Uint64Flag{
+		Name:  "timestamp",
+		Usage: "unix timestamp to evaluate hardfork activations at (defaults to now)",
+	}
+	OutputFlag = &cli.StringFlag{
+		Name:      "output",
+		Usage:     "file to write the schedule to (defaults to stdout)",
+		TakesFile: true,
+	}
+	SourceFlag = &cli.StringFlag{
+		Name:  "source",
+		Usage: "registry to read instead of the working tree: a checkout directory, git:<rev>, or a .tar, .tar.gz or .tgz archive",
+	}
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "hardfork-schedule",
+		Usage: "prints which hardforks are active, pending or unset for every superchain and chain",
+		Flags: []cli.Flag{
+			FormatFlag,
+			TimestampFlag,
+			OutputFlag,
+			SourceFlag,
+		},
+		Action: HardforkScheduleCLI,
+	}
+	if err := app.Run(os.Args); err != nil {
+		output.WriteStderr("%v", err)
+		os.Exit(1)
+	}
+}
+
+func HardforkScheduleCLI(cliCtx *cli.Context) error {
+	timestamp := cliCtx.Uint64(TimestampFlag.Name)
+	if !cliCtx.IsSet(TimestampFlag.Name) {
+		timestamp = uint64(time.Now().Unix())
+	}
+
+	var write func(*manage.HardforkSchedule, io.Writer) error
+	switch format := cliCtx.String(FormatFlag.Name); format {
+	case "json":
+		write = (*manage.HardforkSchedule).WriteJSON
+	case "ics":
+		write = (*manage.HardforkSchedule).WriteICS
+	case "markdown":
+		write = (*manage.HardforkSchedule).WriteMarkdown
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+
+	wd, err := paths.FindRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	src, err := paths.OpenSource(wd, cliCtx.String(SourceFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open source: %w", err)
+	}
+
+	schedule, err := manage.BuildHardforkSchedule(src, timestamp)
+	if err != nil {
+		return fmt.Errorf("failed to build hardfork schedule: %w", err)
+	}
+
+	outPath := cliCtx.String(OutputFlag.Name)
+	if outPath == "" {
+		return write(schedule, os.Stdout)
+	}
+
+	w := fs.NewAtomicWriter(outPath, 0o644)
+	if err := write(schedule, w); err != nil {
+		return fmt.Errorf("failed to write hardfork schedule: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to write hardfork schedule: %w", err)
+	}
+	output.WriteOK("wrote hardfork schedule to %s", outPath)
+	return nil
+}
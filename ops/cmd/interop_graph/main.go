@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/urfave/cli/v2"
+)
+
+var FormatFlag = &cli.StringFlag{
+	Name:  "format",
+	Usage: "output format: dot, mermaid, or json",
+	Value: "dot",
+}
+
+var (
+	SourceFlag = &cli.StringFlag{
+		Name:  "source",
+		Usage: "registry to read instead of the working tree: a checkout directory, git:<rev>, or a .tar, .tar.gz or .tgz archive",
+	}
+	OverlayFlag = &cli.StringSliceFlag{
+		Name:  "overlay",
+		Usage: "directory with the layout of the registry to merge over it, can be repeated",
+	}
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "interop-graph",
+		Usage: "prints the interop dependency graph of every chain, grouped into clusters",
+		Flags: []cli.Flag{
+			FormatFlag,
+			SourceFlag,
+			OverlayFlag,
+		},
+		Action: InteropGraphCLI,
+	}
+	if err := app.Run(os.Args); err != nil {
+		output.WriteNotOK("%v", err)
+		os.Exit(1)
+	}
+}
+
+type graphJSON struct {
+	Clusters        []manage.DependencyCluster `json:"clusters"`
+	Nodes           []manage.DependencyNode    `json:"nodes"`
+	AsymmetricEdges []manage.DependencyEdge    `json:"asymmetricEdges"`
+	DanglingEdges   []manage.DependencyEdge    `json:"danglingEdges"`
+}
+
+func InteropGraphCLI(cliCtx *cli.Context) error {
+	wd, err := paths.FindRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	src, err := paths.OpenSource(wd, cliCtx.String(SourceFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open source: %w", err)
+	}
+
+	overlays, err := paths.OpenOverlays(cliCtx.StringSlice(OverlayFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open overlays: %w", err)
+	}
+
+	reg, err := manage.LoadRegistryOverlay(src, overlays, false)
+	if err != nil {
+		return fmt.Errorf("failed to load registry: %w", err)
+	}
+
+	graph, err := manage.NewDependencyGraph(reg.Chains())
+	if err != nil {
+		return fmt.Errorf("failed to build dependency graph: %w", err)
+	}
+	for _, edge := range graph.AsymmetricEdges() {
+		output.WriteWarn("chain %d depends on chain %d, but not the other way around", edge.From, edge.To)
+	}
+	for _, edge := range graph.DanglingEdges() {
+		output.WriteWarn("chain %d depends on chain %d, which is not in the registry", edge.From, edge.To)
+	}
+
+	switch format := cliCtx.String(FormatFlag.Name); format {
+	case "dot":
+		return graph.WriteDOT(os.Stdout)
+	case "mermaid":
+		return graph.WriteMermaid(os.Stdout)
+	case "json":
+		var interopNodes []manage.DependencyNode
+		for _, node := range graph.Nodes() {
+			if node.Dependencies != nil {
+				interopNodes = append(interopNodes, node)
+			}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(graphJSON{
+			Clusters:        graph.Clusters(),
+			Nodes:           interopNodes,
+			AsymmetricEdges: graph.AsymmetricEdges(),
+			DanglingEdges:   graph.DanglingEdges(),
+		})
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
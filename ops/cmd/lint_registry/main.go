@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	SourceFlag = &cli.StringFlag{
+		Name:  "source",
+		Usage: "registry to lint instead of the working tree: a checkout directory, git:<rev>, or a .tar, .tar.gz or .tgz archive",
+	}
+	OverlayFlag = &cli.StringSliceFlag{
+		Name:  "overlay",
+		Usage: "directory with the layout of the registry to merge over it, can be repeated",
+	}
+	RulesFlag = &cli.StringSliceFlag{
+		Name:  "rules",
+		Usage: "comma-separated list of rule IDs to run (optional, runs every rule that needs no network access if not provided)",
+	}
+	OnlineFlag = &cli.BoolFlag{
+		Name:  "online",
+		Usage: "also run the rules that need network access",
+	}
+	FormatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: "output format: text, json or sarif",
+		Value: "text",
+	}
+	ListRulesFlag = &cli.BoolFlag{
+		Name:  "list-rules",
+		Usage: "print the available rules and exit",
+	}
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "lint-registry",
+		Usage: "runs every lint rule against the registry and reports all findings",
+		Flags: []cli.Flag{
+			SourceFlag,
+			OverlayFlag,
+			RulesFlag,
+			OnlineFlag,
+			FormatFlag,
+			ListRulesFlag,
+		},
+		Action: LintRegistryCLI,
+	}
+	if err := app.Run(os.Args); err != nil {
+		output.WriteNotOK("%v", err)
+		os.Exit(1)
+	}
+}
+
+func LintRegistryCLI(cliCtx *cli.Context) error {
+	if cliCtx.Bool(ListRulesFlag.Name) {
+		for _, rule := range manage.LintRules {
+			online := ""
+			if rule.Online {
+				online = " (online)"
+			}
+			fmt.Printf("%-18s %-7s %s%s\n", rule.ID, rule.Severity, rule.Description, online)
+		}
+		return nil
+	}
+
+	var write func(*manage.LintReport) error
+	switch format := cliCtx.String(FormatFlag.Name); format {
+	case "text":
+		write = func(report *manage.LintReport) error { return report.WriteText(os.Stdout) }
+	case "json":
+		write = func(report *manage.LintReport) error { return report.WriteJSON(os.Stdout) }
+	case "sarif":
+		write = func(report *manage.LintReport) error { return report.WriteSARIF(os.Stdout) }
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	var ruleIDs []string
+	for _, id := range cliCtx.StringSlice(RulesFlag.Name) {
+		if id != "" {
+			ruleIDs = append(ruleIDs, id)
+		}
+	}
+	rules, err := manage.SelectLintRules(ruleIDs, cliCtx.Bool(OnlineFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	wd, err := paths.FindRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	src, err := paths.OpenSource(wd, cliCtx.String(SourceFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open source: %w", err)
+	}
+
+	overlays, err := paths.OpenOverlays(cliCtx.StringSlice(OverlayFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open overlays: %w", err)
+	}
+
+	// Unknown keys are reported by the schema rule, so the registry is not loaded
+	// strictly.
+	reg, err := manage.LoadRegistryOverlay(src, overlays, false)
+	if err != nil {
+		return fmt.Errorf("failed to load registry: %w", err)
+	}
+
+	report := manage.LintRegistry(reg, rules)
+	if err := write(report); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if report.Failed() {
+		return fmt.Errorf("found %d lint errors", report.Count(manage.LintError))
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	DryRunFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "print a diff of every pending migration instead of writing it",
+	}
+	CheckFlag = &cli.BoolFlag{
+		Name:  "check",
+		Usage: "like --dry-run, but fail if any chain config has a pending migration",
+	}
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "migrate-configs",
+		Usage: "applies pending schema migrations to staged and registered chain configs",
+		Flags: []cli.Flag{
+			DryRunFlag,
+			CheckFlag,
+		},
+		Action: MigrateConfigsCLI,
+	}
+	if err := app.Run(os.Args); err != nil {
+		output.WriteNotOK("%v", err)
+		os.Exit(1)
+	}
+}
+
+func MigrateConfigsCLI(cliCtx *cli.Context) error {
+	wd, err := paths.FindRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	check := cliCtx.Bool(CheckFlag.Name)
+	dryRun := cliCtx.Bool(DryRunFlag.Name) || check
+	migrations, err := manage.MigrateConfigs(wd, !dryRun)
+	if err != nil {
+		return err
+	}
+	if len(migrations) == 0 {
+		output.WriteOK("all chain configs are at schema version %d", manage.LatestSchemaVersion())
+		return nil
+	}
+
+	for _, migration := range migrations {
+		if dryRun {
+			fmt.Print(migration.Diff)
+			continue
+		}
+		output.WriteOK("migrated %s from schema version %d to %d", migration.File, migration.FromVersion, migration.ToVersion)
+	}
+	if check {
+		return fmt.Errorf("%d chain configs have pending migrations, run migrate-configs", len(migrations))
+	}
+	if dryRun {
+		output.WriteWarn("%d chain configs have pending migrations", len(migrations))
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/gh"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/l1block"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
@@ -61,6 +62,11 @@ var (
 		Usage:   "The path to the op-deployer binaries cache directory.",
 		EnvVars: []string{"DEPLOYER_CACHE_DIR"},
 	}
+	L1BlockFlag = &cli.StringFlag{
+		Name:    "l1-block",
+		Usage:   "L1 block number or hash to read onchain data at. Defaults to the latest block at the start of the run.",
+		EnvVars: []string{"L1_BLOCK"},
+	}
 )
 
 func main() {
@@ -75,6 +81,7 @@ func main() {
 			GithubTokenFlag,
 			GithubRepoFlag,
 			DeployerCacheDirFlag,
+			L1BlockFlag,
 		},
 		Action: PrintStagingReport,
 	}
@@ -90,6 +97,10 @@ func PrintStagingReport(cliCtx *cli.Context) error {
 	githubToken := cliCtx.String(GithubTokenFlag.Name)
 	githubRepo := cliCtx.String(GithubRepoFlag.Name)
 	deployerCacheDir := cliCtx.String(DeployerCacheDirFlag.Name)
+	l1Block, err := l1block.ParseSpec(cliCtx.String(L1BlockFlag.Name))
+	if err != nil {
+		return fmt.Errorf("invalid L1 block: %w", err)
+	}
 
 	wd, err := paths.FindRepoRoot()
 	if err != nil {
@@ -153,7 +164,7 @@ func PrintStagingReport(cliCtx *cli.Context) error {
 	defer cancel()
 
 	statePath := path.Join(paths.StagingDir(wd), "state.json")
-	allReport := report.ScanAll(ctx, l1RPCURL, rpcClient, statePath, chainCfg, deployerCacheDir)
+	allReport := report.ScanAll(ctx, l1RPCURL, rpcClient, l1Block, statePath, chainCfg, deployerCacheDir)
 	output.WriteOK("scanned L1 and L2")
 
 	comment, err := report.RenderComment(
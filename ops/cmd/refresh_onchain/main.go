@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/l1block"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	L1RPCURLsFlag = &cli.StringSliceFlag{
+		Name:     "l1-rpc-urls",
+		Usage:    "comma-separated list of L1 RPC URLs (only need multiple if fetching from multiple superchains)",
+		EnvVars:  []string{"L1_RPC_URLS"},
+		Required: true,
+	}
+	ChainIDFlag = &cli.Uint64SliceFlag{
+		Name:  "chain-ids",
+		Usage: "comma-separated list of l2 chainIds to refresh (optional, refreshes all chains if not provided)",
+	}
+	SuperchainsFlag = &cli.StringSliceFlag{
+		Name:  "superchains",
+		Usage: "comma-separated list of superchains to refresh (cannot provide both chain-ids and superchains flags, default to all superchains if not provided)",
+	}
+	L1BlocksFlag = &cli.StringSliceFlag{
+		Name:  "l1-blocks",
+		Usage: "comma-separated list of superchain=number or superchain=hash L1 blocks to read onchain data at (defaults to the latest block of each superchain at the start of the run)",
+	}
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "refresh-onchain",
+		Usage: "fetches the onchain config of chain(s) in the superchain-registry and updates the snapshots that codegen --from-snapshots reads",
+		Flags: []cli.Flag{
+			L1RPCURLsFlag,
+			ChainIDFlag,
+			SuperchainsFlag,
+			L1BlocksFlag,
+		},
+		Action: RefreshOnchainCLI,
+	}
+	if err := app.Run(os.Args); err != nil {
+		output.WriteNotOK("%v", err)
+		os.Exit(1)
+	}
+}
+
+func RefreshOnchainCLI(cliCtx *cli.Context) error {
+	chainIds := cliCtx.Uint64Slice(ChainIDFlag.Name)
+	var superchains []string
+	for _, sc := range cliCtx.StringSlice(SuperchainsFlag.Name) {
+		if sc != "" {
+			superchains = append(superchains, sc)
+		}
+	}
+	if len(chainIds) > 0 && len(superchains) > 0 {
+		return fmt.Errorf("cannot provide both chain-ids and superchains flags")
+	}
+	l1Blocks, err := l1block.ParseSpecs(cliCtx.StringSlice(L1BlocksFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	lgr := log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stderr, log.LevelInfo, false))
+	wd, err := paths.FindRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	fsys := fs.NewDirFS(wd)
+	reg, err := manage.LoadRegistryFS(fsys, false)
+	if err != nil {
+		return fmt.Errorf("failed to load registry: %w", err)
+	}
+
+	snapshots, err := manage.FetchChains(cliCtx.Context, lgr, reg, cliCtx.StringSlice(L1RPCURLsFlag.Name), chainIds, superchains, l1Blocks)
+	if err != nil {
+		return fmt.Errorf("error fetching onchain configs: %w", err)
+	}
+	updated, err := manage.WriteOnchainSnapshots(fsys, snapshots)
+	if err != nil {
+		return err
+	}
+	for _, chainID := range updated {
+		output.WriteOK("updated onchain snapshot of chain %d at L1 block %d", chainID, snapshots[chainID].L1Block.Number)
+	}
+
+	// Only a full refresh knows which snapshots are left over.
+	if len(chainIds) == 0 && len(superchains) == 0 {
+		removed, err := manage.PruneOnchainSnapshots(fsys, reg)
+		if err != nil {
+			return err
+		}
+		for _, chainID := range removed {
+			output.WriteOK("removed onchain snapshot of removed chain %d", chainID)
+		}
+	}
+
+	output.WriteOK("refreshed %d onchain snapshots, %d changed", len(snapshots), len(updated))
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	BaseFlag = &cli.StringFlag{
+		Name:     "base",
+		Usage:    "registry to diff from: a checkout directory, git:<rev>, or a .tar, .tar.gz or .tgz archive",
+		Required: true,
+	}
+	HeadFlag = &cli.StringFlag{
+		Name:  "head",
+		Usage: "registry to diff to, in the same forms as --base (defaults to the working tree)",
+	}
+	FormatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: "output format: markdown or json",
+		Value: "markdown",
+	}
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "registry-diff",
+		Usage: "prints the semantic changes between two registry snapshots",
+		Flags: []cli.Flag{
+			BaseFlag,
+			HeadFlag,
+			FormatFlag,
+		},
+		Action: RegistryDiffCLI,
+	}
+	if err := app.Run(os.Args); err != nil {
+		output.WriteNotOK("%v", err)
+		os.Exit(1)
+	}
+}
+
+func RegistryDiffCLI(cliCtx *cli.Context) error {
+	wd, err := paths.FindRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	base, err := loadRegistry(wd, cliCtx.String(BaseFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to load base registry: %w", err)
+	}
+	head, err := loadRegistry(wd, cliCtx.String(HeadFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to load head registry: %w", err)
+	}
+
+	diff := manage.DiffRegistries(base, head)
+	switch format := cliCtx.String(FormatFlag.Name); format {
+	case "markdown":
+		return diff.WriteMarkdown(os.Stdout)
+	case "json":
+		return diff.WriteJSON(os.Stdout)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func loadRegistry(wd string, source string) (*manage.Registry, error) {
+	src, err := paths.OpenSource(wd, source)
+	if err != nil {
+		return nil, err
+	}
+	return manage.LoadRegistryFS(src, false)
+}
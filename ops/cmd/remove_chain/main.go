@@ -1,9 +1,12 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	iofs "io/fs"
 	"os"
 
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
@@ -11,11 +14,20 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
-var ChainIDFlag = &cli.Uint64Flag{
-	Name:     "chain-id",
-	Usage:    "chain ID of the chain to remove",
-	Required: true,
-}
+var (
+	ChainIDFlag = &cli.Uint64Flag{
+		Name:  "chain-id",
+		Usage: "chain ID of the chain to remove",
+	}
+	ResumeFlag = &cli.BoolFlag{
+		Name:  "resume",
+		Usage: "apply the remaining changes of a removal that did not finish, then exit",
+	}
+	RevertFlag = &cli.BoolFlag{
+		Name:  "revert",
+		Usage: "undo the changes of a removal that did not finish, then exit",
+	}
+)
 
 func main() {
 	app := &cli.App{
@@ -23,6 +35,8 @@ func main() {
 		Usage: "removes a chain config TOML and genesis file from the registry",
 		Flags: []cli.Flag{
 			ChainIDFlag,
+			ResumeFlag,
+			RevertFlag,
 		},
 		Action: RemoveChainCLI,
 	}
@@ -33,49 +47,85 @@ func main() {
 }
 
 func RemoveChainCLI(cliCtx *cli.Context) error {
-	chainID := cliCtx.Uint64("chain-id")
-
 	lgr := log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stderr, log.LevelInfo, false))
 	wd, err := paths.FindRepoRoot()
 	if err != nil {
 		return fmt.Errorf("failed to get working directory: %w", err)
 	}
 
-	// Collect all chain configs to find the one with matching chainID
-	configs, err := manage.CollectChainConfigs(paths.SuperchainConfigsDir(wd))
+	switch {
+	case cliCtx.Bool(ResumeFlag.Name):
+		ops, err := fs.ResumeTx(wd)
+		if err != nil {
+			return err
+		}
+		output.WriteOK("resumed removal, applied %d changes", len(ops))
+		return nil
+	case cliCtx.Bool(RevertFlag.Name):
+		ops, err := fs.RevertTx(wd)
+		if err != nil {
+			return err
+		}
+		output.WriteOK("reverted removal, restored %d files", len(ops))
+		return nil
+	case !cliCtx.IsSet(ChainIDFlag.Name):
+		return fmt.Errorf("required flag \"%s\" not set", ChainIDFlag.Name)
+	}
+	chainID := cliCtx.Uint64(ChainIDFlag.Name)
+
+	// The config and genesis are removed together, or not at all.
+	tx, err := fs.BeginTx(wd)
 	if err != nil {
-		return fmt.Errorf("error collecting chain configs: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w (rerun with --%s or --%s)", err, ResumeFlag.Name, RevertFlag.Name)
 	}
 
-	var foundConfig *manage.DiskChainConfig
-	for i := range configs {
-		if configs[i].Config.ChainID == chainID {
-			foundConfig = &configs[i]
-			break
-		}
+	reg, err := manage.LoadRegistryFS(tx, false)
+	if err != nil {
+		return fmt.Errorf("error loading registry: %w", err)
 	}
 
-	if foundConfig == nil {
+	foundConfig, ok := reg.ChainByID(chainID)
+	if !ok {
 		return fmt.Errorf("chain with chain ID %d not found", chainID)
 	}
 
 	// Delete the chain config TOML file
-	configPath := foundConfig.Filepath
-	if err := os.Remove(configPath); err != nil {
+	configPath := paths.ChainConfig(".", foundConfig.Superchain, foundConfig.ShortName)
+	if err := tx.Remove(configPath); err != nil {
 		return fmt.Errorf("error removing chain config file %s: %w", configPath, err)
 	}
-	output.WriteOK("removed chain config: %s", configPath)
 
 	// Delete the genesis file
-	genesisPath := paths.GenesisFile(wd, foundConfig.Superchain, foundConfig.ShortName)
-	if err := os.Remove(genesisPath); err != nil {
+	genesisPath := paths.GenesisFile(".", foundConfig.Superchain, foundConfig.ShortName)
+	genesisRemoved := true
+	if err := tx.Remove(genesisPath); err != nil {
 		// If genesis file doesn't exist, that's okay - just log a warning
-		if !os.IsNotExist(err) {
+		if !errors.Is(err, iofs.ErrNotExist) {
 			return fmt.Errorf("error removing genesis file %s: %w", genesisPath, err)
 		}
 		lgr.Warn("genesis file does not exist, skipping", "path", genesisPath)
-	} else {
-		output.WriteOK("removed genesis file: %s", genesisPath)
+		genesisRemoved = false
+	}
+
+	// Delete the onchain snapshot, which only exists if snapshots were refreshed
+	snapshotPath := paths.OnchainSnapshotFile(".", chainID)
+	snapshotRemoved := true
+	if err := tx.Remove(snapshotPath); err != nil {
+		if !errors.Is(err, iofs.ErrNotExist) {
+			return fmt.Errorf("error removing onchain snapshot %s: %w", snapshotPath, err)
+		}
+		snapshotRemoved = false
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit changes: %w", err)
+	}
+	output.WriteOK("removed chain config: %s", foundConfig.Filepath)
+	if genesisRemoved {
+		output.WriteOK("removed genesis file: %s", tx.Path(genesisPath))
+	}
+	if snapshotRemoved {
+		output.WriteOK("removed onchain snapshot: %s", tx.Path(snapshotPath))
 	}
 
 	return nil
@@ -3,10 +3,13 @@ package main
 import (
 	"errors"
 	"fmt"
+	iofs "io/fs"
 	"os"
 	"path"
 
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/l1block"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
@@ -24,9 +27,20 @@ var (
 		Usage: "Skip cleanup of staging directory.",
 	}
 	FlagL1RPCURLs = &cli.StringSliceFlag{
-		Name:     "l1-rpc-urls",
-		Usage:    "Comma-separated list of L1 RPC URLs",
-		Required: true,
+		Name:  "l1-rpc-urls",
+		Usage: "Comma-separated list of L1 RPC URLs",
+	}
+	FlagL1Blocks = &cli.StringSliceFlag{
+		Name:  "l1-blocks",
+		Usage: "Comma-separated list of superchain=number or superchain=hash L1 blocks to read onchain data at. Defaults to the latest block at the start of the sync.",
+	}
+	FlagResume = &cli.BoolFlag{
+		Name:  "resume",
+		Usage: "Apply the remaining changes of a sync that did not finish, then exit.",
+	}
+	FlagRevert = &cli.BoolFlag{
+		Name:  "revert",
+		Usage: "Undo the changes of a sync that did not finish, then exit.",
 	}
 )
 
@@ -38,6 +52,9 @@ func main() {
 			FlagCheck,
 			FlagPreserveInput,
 			FlagL1RPCURLs,
+			FlagL1Blocks,
+			FlagResume,
+			FlagRevert,
 		},
 		Action: action,
 	}
@@ -48,17 +65,62 @@ func main() {
 }
 
 func action(cliCtx *cli.Context) error {
+	wd, err := paths.FindRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	switch {
+	case cliCtx.Bool(FlagResume.Name):
+		ops, err := fs.ResumeTx(wd)
+		if err != nil {
+			return err
+		}
+		output.WriteOK("resumed sync, applied %d changes", len(ops))
+		return nil
+	case cliCtx.Bool(FlagRevert.Name):
+		ops, err := fs.RevertTx(wd)
+		if err != nil {
+			return err
+		}
+		output.WriteOK("reverted sync, restored %d files", len(ops))
+		return nil
+	}
+
+	// All changes are staged in tx and committed together at the end, so a
+	// failure midway leaves the registry untouched.
+	tx, err := fs.BeginTx(wd)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w (rerun with --%s or --%s)", err, FlagResume.Name, FlagRevert.Name)
+	}
+	if err := syncStaging(cliCtx, wd, tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit changes: %w", err)
+	}
+	output.WriteOK("committed changes")
+	return nil
+}
+
+func syncStaging(cliCtx *cli.Context, wd string, tx *fs.Tx) error {
 	lgr := log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stdout, log.LevelInfo, true))
 	l1RpcUrls := cliCtx.StringSlice(FlagL1RPCURLs.Name)
 	check := cliCtx.Bool(FlagCheck.Name)
+	// Not marked required on the flag, since --resume, --revert and --check
+	// make no onchain reads.
+	if !check && len(l1RpcUrls) == 0 {
+		return fmt.Errorf("required flag \"%s\" not set", FlagL1RPCURLs.Name)
+	}
 	preserveInput := cliCtx.Bool(FlagPreserveInput.Name)
-	wd, err := paths.FindRepoRoot()
+	l1Blocks, err := l1block.ParseSpecs(cliCtx.StringSlice(FlagL1Blocks.Name))
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
 
 	l1RpcUrl := ""
 	stagingDir := paths.StagingDir(wd)
+	stagingName := paths.StagingDir(".")
 	stagedSuperchainDefinition, err := manage.StagedSuperchainDefinition(wd)
 	if err != nil {
 		if errors.Is(err, manage.ErrNoStagedSuperchainDefinition) {
@@ -68,30 +130,38 @@ func action(cliCtx *cli.Context) error {
 		}
 	} else {
 		// Process the superchain definition
-		output.WriteOK("superchain definition found, finding L1 RPC URL...")
-		l1RpcUrl, err = config.FindValidL1URL(cliCtx.Context,
-			lgr,
-			l1RpcUrls, stagedSuperchainDefinition.L1.ChainID)
-		if err != nil {
-			return fmt.Errorf("failed to find valid L1 RPC URL: %w", err)
+		if err := stagedSuperchainDefinition.Hardforks.ValidateOrder(); err != nil {
+			return fmt.Errorf("invalid hardforks in staged superchain definition: %w", err)
 		}
-		stagedSuperchainDefinition.L1.PublicRPC = l1RpcUrl
-		err = manage.WriteSuperchainDefinition(
-			paths.SuperchainDefinitionPath(wd, config.Superchain(stagedSuperchainDefinition.Name)),
-			stagedSuperchainDefinition)
-		if err != nil {
-			return fmt.Errorf("failed to write superchain definition: %w", err)
+		if check {
+			output.WriteOK("superchain definition validation successful")
+		} else {
+			output.WriteOK("superchain definition found, finding L1 RPC URL...")
+			l1RpcUrl, err = config.FindValidL1URL(cliCtx.Context,
+				lgr,
+				l1RpcUrls, stagedSuperchainDefinition.L1.ChainID)
+			if err != nil {
+				return fmt.Errorf("failed to find valid L1 RPC URL: %w", err)
+			}
+			stagedSuperchainDefinition.L1.PublicRPC = l1RpcUrl
+			err = manage.WriteSuperchainDefinitionFS(
+				tx,
+				paths.SuperchainDefinitionPath(".", config.Superchain(stagedSuperchainDefinition.Name)),
+				stagedSuperchainDefinition)
+			if err != nil {
+				return fmt.Errorf("failed to write superchain definition: %w", err)
+			}
+			output.WriteOK("staged superchain definition")
 		}
-		output.WriteOK("wrote superchain definition")
 	}
 
-	if !preserveInput {
+	if !preserveInput && !check {
 		// Check if file exists first
-		superchainTomlPath := path.Join(stagingDir, "superchain.toml")
-		_, err := os.Stat(superchainTomlPath)
+		superchainTomlPath := path.Join(stagingName, "superchain.toml")
+		_, err := iofs.Stat(tx, superchainTomlPath)
 		if err == nil {
 			// File exists, try to remove it
-			if err := os.Remove(superchainTomlPath); err != nil {
+			if err := tx.Remove(superchainTomlPath); err != nil {
 				output.WriteNotOK("failed to remove %s: %v", superchainTomlPath, err)
 			} else {
 				output.WriteOK("cleaned superchain definition from staging directory")
@@ -114,6 +184,11 @@ func action(cliCtx *cli.Context) error {
 		return fmt.Errorf("failed to get staged chain config: %w", err)
 	}
 
+	reg, err := manage.LoadRegistryFS(tx, false)
+	if err != nil {
+		return fmt.Errorf("failed to load registry: %w", err)
+	}
+
 	var chainIds []uint64
 	for _, chainCfg := range stagedChainCfgs {
 		genesisFilename := path.Join(stagingDir, chainCfg.ShortName+".json.zst")
@@ -122,49 +197,59 @@ func action(cliCtx *cli.Context) error {
 			return fmt.Errorf("failed to read genesis: %w", err)
 		}
 
-		superchainPath := paths.SuperchainDir(wd, chainCfg.Superchain)
-		chainCfgs, err := manage.CollectChainConfigs(superchainPath)
-		if err != nil {
-			return fmt.Errorf("failed to collect chain configs: %w", err)
+		if err := manage.ValidateSchemaVersion(&chainCfg.Chain); err != nil {
+			return fmt.Errorf("failed schema version check: %w", err)
 		}
+		output.WriteOK("schema version check passed")
 
-		if err := manage.ValidateUniqueness(chainCfg, chainCfgs); err != nil {
+		if err := manage.ValidateUniqueness(chainCfg, reg.ChainsBySuperchain(chainCfg.Superchain)); err != nil {
 			return fmt.Errorf("failed uniqueness check: %w", err)
 		}
 		output.WriteOK("internal uniqueness check passed")
 
+		if err := chainCfg.Hardforks.ValidateOrder(); err != nil {
+			return fmt.Errorf("failed hardfork order check: %w", err)
+		}
+		output.WriteOK("hardfork order check passed")
+
 		if check {
 			output.WriteOK("validation successful")
 			continue
 		}
 
-		if err := manage.WriteChainConfig(wd, chainCfg); err != nil {
+		if err := manage.WriteChainConfigFS(tx, chainCfg); err != nil {
 			return fmt.Errorf("failed to write chain config: %w", err)
 		}
 
 		output.WriteOK(
-			"wrote chain config %s.toml to %s superchain",
+			"staged chain config %s.toml to %s superchain",
 			chainCfg.ShortName,
 			chainCfg.Superchain,
 		)
 
-		if err := manage.WriteSuperchainGenesis(wd, chainCfg.Superchain, chainCfg.ShortName, genesis); err != nil {
+		if err := manage.WriteSuperchainGenesisFS(tx, chainCfg.Superchain, chainCfg.ShortName, genesis); err != nil {
 			return fmt.Errorf("failed to write genesis: %w", err)
 		}
 
-		output.WriteOK("wrote genesis files")
+		output.WriteOK("staged genesis files")
+
+		// Later staged chains must be unique against this one too.
+		if err := reg.Reload(); err != nil {
+			return fmt.Errorf("failed to reload registry: %w", err)
+		}
 
 		if !preserveInput {
-			cfgFilename := path.Join(stagingDir, chainCfg.ShortName+".toml")
-			if err := os.Remove(cfgFilename); err != nil {
+			cfgFilename := path.Join(stagingName, chainCfg.ShortName+".toml")
+			if err := tx.Remove(cfgFilename); err != nil {
 				output.WriteNotOK("failed to remove %s: %v", cfgFilename, err)
 			}
-			if err := os.Remove(genesisFilename); err != nil {
-				output.WriteNotOK("failed to remove %s: %v", genesisFilename, err)
+			genesisName := path.Join(stagingName, chainCfg.ShortName+".json.zst")
+			if err := tx.Remove(genesisName); err != nil {
+				output.WriteNotOK("failed to remove %s: %v", genesisName, err)
 			}
-			stateFilename := path.Join(stagingDir, "state.json")
-			if _, err := os.Stat(stateFilename); err == nil {
-				if err := os.Remove(stateFilename); err != nil {
+			stateFilename := path.Join(stagingName, "state.json")
+			if _, err := iofs.Stat(tx, stateFilename); err == nil {
+				if err := tx.Remove(stateFilename); err != nil {
 					output.WriteNotOK("failed to remove %s: %v", stateFilename, err)
 				}
 			}
@@ -173,14 +258,17 @@ func action(cliCtx *cli.Context) error {
 		}
 		chainIds = append(chainIds, chainCfg.ChainID)
 	}
+	if check {
+		return nil
+	}
 
 	// Codegen
 	ctx := cliCtx.Context
-	onchainCfgs, err := manage.FetchChains(ctx, lgr, wd, l1RpcUrls, chainIds, []config.Superchain{})
+	snapshots, err := manage.FetchChains(ctx, lgr, reg, l1RpcUrls, chainIds, []config.Superchain{}, l1Blocks)
 	if err != nil {
 		return fmt.Errorf("error fetching onchain configs: %w", err)
 	}
-	syncer, err := manage.NewCodegenSyncer(lgr, wd, onchainCfgs)
+	syncer, err := manage.NewSnapshotCodegenSyncer(lgr, reg, snapshots, manage.WithL1Blocks(manage.PinnedL1Blocks(reg, snapshots, l1Blocks)))
 	if err != nil {
 		return fmt.Errorf("error creating codegen syncer: %w", err)
 	}
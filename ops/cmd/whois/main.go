@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/output"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	AddressFlag = &cli.StringFlag{
+		Name:     "address",
+		Usage:    "L1 address to look up, in any case",
+		Required: true,
+	}
+	JSONFlag = &cli.BoolFlag{
+		Name:  "json",
+		Usage: "print matches as JSON",
+	}
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "whois",
+		Usage: "reports every chain, contract slot and role an address appears in",
+		Flags: []cli.Flag{
+			AddressFlag,
+			JSONFlag,
+		},
+		Action: WhoisCLI,
+	}
+	if err := app.Run(os.Args); err != nil {
+		output.WriteStderr("%v", err)
+		os.Exit(1)
+	}
+}
+
+func WhoisCLI(cliCtx *cli.Context) error {
+	addrStr := cliCtx.String(AddressFlag.Name)
+	if !common.IsHexAddress(addrStr) {
+		return fmt.Errorf("invalid address: %s", addrStr)
+	}
+	addr := common.HexToAddress(addrStr)
+
+	wd, err := paths.FindRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	matches, err := manage.Whois(wd, addr)
+	if err != nil {
+		return fmt.Errorf("failed to look up %s: %w", addr, err)
+	}
+
+	if cliCtx.Bool(JSONFlag.Name) {
+		if matches == nil {
+			matches = []manage.WhoisMatch{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(matches)
+	}
+
+	if len(matches) == 0 {
+		output.WriteWarn("%s does not appear in the registry", addr)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FILE\tCHAIN ID\tKEY\tVALUE")
+	for _, match := range matches {
+		chainID := "-"
+		if match.ChainID != 0 {
+			chainID = fmt.Sprintf("%d", match.ChainID)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", match.File, chainID, match.Key, match.Value)
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write matches: %w", err)
+	}
+	output.WriteOK("found %s in %d places", addr, len(matches))
+	return nil
+}
@@ -65,7 +65,7 @@ func (a *ChecksummedAddress) parseAddress(addrStr string) error {
 
 	// Validate that the address is properly checksummed
 	if addr.Hex() != addrStr {
-		return fmt.Errorf("invalid checksummed address: %s", addrStr)
+		return fmt.Errorf("invalid checksummed address: %s (run `just fix-checksums` to fix)", addrStr)
 	}
 
 	*a = ChecksummedAddress(addr)
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/ethereum-optimism/optimism/op-chain-ops/addresses"
@@ -30,14 +32,31 @@ type Interop struct {
 	Dependencies map[string]StaticConfigDependency `json:"dependencies" toml:"dependencies"`
 }
 
+// DependencyChainIDs returns the chain IDs of the interop dependencies, sorted.
+// Dependencies are keyed by stringified chain IDs, so this fails if any key is
+// not a valid uint64.
+func (i *Interop) DependencyChainIDs() ([]uint64, error) {
+	ids := make([]uint64, 0, len(i.Dependencies))
+	for key := range i.Dependencies {
+		id, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dependency chain ID %q: %w", key, err)
+		}
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
 type Chain struct {
+	SchemaVersion        uint64              `toml:"schema_version"`
 	Name                 string              `toml:"name"`
 	PublicRPC            string              `toml:"public_rpc"`
 	SequencerRPC         string              `toml:"sequencer_rpc"`
 	Explorer             string              `toml:"explorer"`
 	GovernedByOptimism   bool                `toml:"governed_by_optimism"`
 	SuperchainTime       *uint64             `toml:"superchain_time"`
-	DataAvailabilityType string              `toml:"data_availability_type"`
+	DataAvailabilityType string              `toml:"data_availability_type" jsonschema:"enum=eth-da,enum=alt-da"`
 	ChainID              uint64              `toml:"chain_id"`
 	BatchInboxAddr       *ChecksummedAddress `toml:"batch_inbox_addr"`
 	BlockTime            uint64              `toml:"block_time"`
@@ -106,11 +125,19 @@ type SystemConfig struct {
 	BlobBaseFeeScalar *uint64            `json:"blobBaseFeeScalar,omitempty" toml:"blobBaseFeeScalar,omitempty"`
 }
 
+const (
+	EthDADataAvailabilityType = "eth-da"
+	AltDADataAvailabilityType = "alt-da"
+
+	KeccakCommitmentType  = "KeccakCommitment"
+	GenericCommitmentType = "GenericCommitment"
+)
+
 type AltDA struct {
 	DaChallengeContractAddress ChecksummedAddress `toml:"da_challenge_contract_address"`
 	DaChallengeWindow          uint64             `toml:"da_challenge_window"`
 	DaResolveWindow            uint64             `toml:"da_resolve_window"`
-	DaCommitmentType           string             `toml:"da_commitment_type"`
+	DaCommitmentType           string             `toml:"da_commitment_type" jsonschema:"enum=KeccakCommitment,enum=GenericCommitment"`
 }
 
 type Optimism struct {
@@ -10,11 +10,24 @@ type ChainListEntry struct {
 	DataAvailabilityType string               `json:"dataAvailabilityType" toml:"data_availability_type"`
 	Parent               ChainListEntryParent `json:"parent" toml:"parent"`
 	GasPayingToken       *ChecksummedAddress  `json:"gasPayingToken,omitempty" toml:"gas_paying_token,omitempty"`
+	NativeCurrency       *NativeCurrency      `json:"nativeCurrency,omitempty" toml:"native_currency,omitempty"`
 	FaultProofs          FaultProofs          `json:"faultProofs,omitempty" toml:"fault_proofs,omitempty"`
 }
 
+// NativeCurrency is the ERC-20 metadata of a chain's custom gas paying token.
+// It is only set for chains with a custom gas paying token; chains without one
+// use ether.
+type NativeCurrency struct {
+	Name     string `json:"name" toml:"name"`
+	Symbol   string `json:"symbol" toml:"symbol"`
+	Decimals uint8  `json:"decimals" toml:"decimals"`
+}
+
 type FaultProofs struct {
 	Status string `json:"status" toml:"status"`
+	// RespectedGameType is the name of the game type the OptimismPortal
+	// respects, or its ID if it is not in the game type registry.
+	RespectedGameType string `json:"respectedGameType,omitempty" toml:"respected_game_type,omitempty"`
 }
 
 type ChainListEntryParent struct {
@@ -62,3 +62,18 @@ func TestAddressesWithRoles_Marshaling(t *testing.T) {
 	require.NoError(t, json.NewEncoder(&buf).Encode(testData))
 	require.JSONEq(t, string(expData), buf.String())
 }
+
+func TestInterop_DependencyChainIDs(t *testing.T) {
+	interop := &Interop{Dependencies: map[string]StaticConfigDependency{
+		"902": {},
+		"901": {},
+		"10":  {},
+	}}
+	ids, err := interop.DependencyChainIDs()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{10, 901, 902}, ids)
+
+	interop.Dependencies["op"] = StaticConfigDependency{}
+	_, err = interop.DependencyChainIDs()
+	require.ErrorContains(t, err, `invalid dependency chain ID "op"`)
+}
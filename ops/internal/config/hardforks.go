@@ -1,11 +1,13 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"reflect"
 	"time"
 )
 
+var ErrHardforkOutOfOrder = errors.New("hardfork activates before its predecessor")
+
 type HardforkTime uint64
 
 func NewHardforkTime(t uint64) *HardforkTime {
@@ -26,6 +28,227 @@ func (h *HardforkTime) U64Ptr() *uint64 {
 	return (*uint64)(h)
 }
 
+// Hardfork describes a single network upgrade tracked by the registry.
+type Hardfork struct {
+	// Name is the human-readable name of the fork, e.g. "Holocene".
+	Name string
+	// TOMLKey is the key holding the activation time in a [hardforks] table.
+	TOMLKey string
+	// DeployConfigOffset is the op-deployer UpgradeScheduleDeployConfig field
+	// holding the fork's activation offset from L2 genesis.
+	DeployConfigOffset string
+	// RollupField is the op-node rollup.Config field holding the fork's
+	// activation time.
+	RollupField string
+	// OpGethFields are the op-geth params.ChainConfig fields driven by the
+	// fork's activation time. Empty if op-geth does not track the fork.
+	OpGethFields []string
+	// Unordered forks are scheduled independently of the main upgrade sequence
+	// and are skipped when validating activation order.
+	Unordered bool
+	// Corrective forks keep an older behavior until their activation time
+	// rather than introducing a new one from it, so they have no effect on
+	// chains whose genesis is at or after that time, and are not inherited by
+	// them.
+	Corrective bool
+
+	field func(*Hardforks) **HardforkTime
+}
+
+// Time returns the fork's activation time in h, or nil if it is unset.
+func (f Hardfork) Time(h *Hardforks) *HardforkTime {
+	return *f.field(h)
+}
+
+// SetTime sets the fork's activation time in h.
+func (f Hardfork) SetTime(h *Hardforks, t *HardforkTime) {
+	*f.field(h) = t
+}
+
+// AllHardforks is the catalogue of every hardfork in activation order. Adding a
+// new fork requires a field in Hardforks and an entry here; every consumer of
+// hardfork times is driven from this list.
+var AllHardforks = []Hardfork{
+	{
+		Name:               "Canyon",
+		TOMLKey:            "canyon_time",
+		DeployConfigOffset: "L2GenesisCanyonTimeOffset",
+		RollupField:        "CanyonTime",
+		OpGethFields:       []string{"ShanghaiTime", "CanyonTime"}, // Shanghai activates with Canyon
+		field:              func(h *Hardforks) **HardforkTime { return &h.CanyonTime },
+	},
+	{
+		Name:               "Delta",
+		TOMLKey:            "delta_time",
+		DeployConfigOffset: "L2GenesisDeltaTimeOffset",
+		RollupField:        "DeltaTime",
+		field:              func(h *Hardforks) **HardforkTime { return &h.DeltaTime },
+	},
+	{
+		Name:               "Ecotone",
+		TOMLKey:            "ecotone_time",
+		DeployConfigOffset: "L2GenesisEcotoneTimeOffset",
+		RollupField:        "EcotoneTime",
+		OpGethFields:       []string{"CancunTime", "EcotoneTime"}, // Cancun activates with Ecotone
+		field:              func(h *Hardforks) **HardforkTime { return &h.EcotoneTime },
+	},
+	{
+		Name:               "Fjord",
+		TOMLKey:            "fjord_time",
+		DeployConfigOffset: "L2GenesisFjordTimeOffset",
+		RollupField:        "FjordTime",
+		OpGethFields:       []string{"FjordTime"},
+		field:              func(h *Hardforks) **HardforkTime { return &h.FjordTime },
+	},
+	{
+		Name:               "Granite",
+		TOMLKey:            "granite_time",
+		DeployConfigOffset: "L2GenesisGraniteTimeOffset",
+		RollupField:        "GraniteTime",
+		OpGethFields:       []string{"GraniteTime"},
+		field:              func(h *Hardforks) **HardforkTime { return &h.GraniteTime },
+	},
+	{
+		Name:               "Holocene",
+		TOMLKey:            "holocene_time",
+		DeployConfigOffset: "L2GenesisHoloceneTimeOffset",
+		RollupField:        "HoloceneTime",
+		OpGethFields:       []string{"HoloceneTime"},
+		field:              func(h *Hardforks) **HardforkTime { return &h.HoloceneTime },
+	},
+	{
+		// The Pectra blob schedule fix follows L1's Pectra activation rather than
+		// the L2 upgrade sequence, so chains may schedule it before Holocene.
+		Name:               "PectraBlobSchedule",
+		TOMLKey:            "pectra_blob_schedule_time",
+		DeployConfigOffset: "L2GenesisPectraBlobScheduleTimeOffset",
+		RollupField:        "PectraBlobScheduleTime",
+		Unordered:          true,
+		Corrective:         true,
+		field:              func(h *Hardforks) **HardforkTime { return &h.PectraBlobScheduleTime },
+	},
+	{
+		Name:               "Isthmus",
+		TOMLKey:            "isthmus_time",
+		DeployConfigOffset: "L2GenesisIsthmusTimeOffset",
+		RollupField:        "IsthmusTime",
+		OpGethFields:       []string{"PragueTime", "IsthmusTime"}, // Prague activates with Isthmus
+		field:              func(h *Hardforks) **HardforkTime { return &h.IsthmusTime },
+	},
+	{
+		Name:               "Jovian",
+		TOMLKey:            "jovian_time",
+		DeployConfigOffset: "L2GenesisJovianTimeOffset",
+		RollupField:        "JovianTime",
+		OpGethFields:       []string{"JovianTime"},
+		field:              func(h *Hardforks) **HardforkTime { return &h.JovianTime },
+	},
+	{
+		Name:               "Karst",
+		TOMLKey:            "karst_time",
+		DeployConfigOffset: "L2GenesisKarstTimeOffset",
+		RollupField:        "KarstTime",
+		field:              func(h *Hardforks) **HardforkTime { return &h.KarstTime },
+	},
+	{
+		Name:               "Lagoon",
+		TOMLKey:            "lagoon_time",
+		DeployConfigOffset: "L2GenesisLagoonTimeOffset",
+		RollupField:        "LagoonTime",
+		OpGethFields:       []string{"LagoonTime"},
+		field:              func(h *Hardforks) **HardforkTime { return &h.LagoonTime },
+	},
+}
+
+// HardforkByDeployConfigOffset returns the catalogue entry for the given
+// op-deployer offset field name.
+func HardforkByDeployConfigOffset(fieldName string) (Hardfork, bool) {
+	for _, fork := range AllHardforks {
+		if fork.DeployConfigOffset == fieldName {
+			return fork, true
+		}
+	}
+	return Hardfork{}, false
+}
+
+type HardforkStatus string
+
+const (
+	HardforkActive  HardforkStatus = "active"
+	HardforkPending HardforkStatus = "pending"
+	HardforkUnset   HardforkStatus = "unset"
+)
+
+// HardforkActivation is the status of a single hardfork at a given timestamp.
+type HardforkActivation struct {
+	Name   string         `json:"name"`
+	Key    string         `json:"key"`
+	Time   *uint64        `json:"time,omitempty"`
+	Status HardforkStatus `json:"status"`
+}
+
+// StatusAt reports the status of every catalogued hardfork in h at timestamp t.
+// A fork is active once t reaches its activation time.
+func (h *Hardforks) StatusAt(t uint64) []HardforkActivation {
+	out := make([]HardforkActivation, len(AllHardforks))
+	for i, fork := range AllHardforks {
+		activation := HardforkActivation{
+			Name:   fork.Name,
+			Key:    fork.TOMLKey,
+			Time:   fork.Time(h).U64Ptr(),
+			Status: HardforkUnset,
+		}
+		if activation.Time != nil {
+			if t >= *activation.Time {
+				activation.Status = HardforkActive
+			} else {
+				activation.Status = HardforkPending
+			}
+		}
+		out[i] = activation
+	}
+	return out
+}
+
+// ActiveAt returns the hardforks in h that are active at timestamp t.
+func (h *Hardforks) ActiveAt(t uint64) []Hardfork {
+	var out []Hardfork
+	for _, fork := range AllHardforks {
+		if ft := fork.Time(h); ft != nil && t >= uint64(*ft) {
+			out = append(out, fork)
+		}
+	}
+	return out
+}
+
+// ValidateOrder checks that no hardfork in h activates before an earlier fork
+// in the catalogue. Unset forks and unordered forks are skipped.
+func (h *Hardforks) ValidateOrder() error {
+	var prev Hardfork
+	var prevTime *HardforkTime
+	for _, fork := range AllHardforks {
+		if fork.Unordered {
+			continue
+		}
+
+		t := fork.Time(h)
+		if t == nil {
+			continue
+		}
+
+		if prevTime != nil && *t < *prevTime {
+			return fmt.Errorf(
+				"%w: %s (%d) is before %s (%d)",
+				ErrHardforkOutOfOrder,
+				fork.TOMLKey, uint64(*t),
+				prev.TOMLKey, uint64(*prevTime),
+			)
+		}
+		prev, prevTime = fork, t
+	}
+	return nil
+}
+
 func CopyHardforks(src, dst *Hardforks, superchainTime, genesisTime *uint64) error {
 	if superchainTime == nil {
 		// No changes if SuperchainTime is unset
@@ -36,43 +259,29 @@ func CopyHardforks(src, dst *Hardforks, superchainTime, genesisTime *uint64) err
 		return fmt.Errorf("genesisTime is nil")
 	}
 
-	srcVal := reflect.ValueOf(src).Elem()
-	dstVal := reflect.ValueOf(dst).Elem()
-
-	// Iterate through all fields in the source struct
-	for i := 0; i < srcVal.NumField(); i++ {
-		srcField := srcVal.Field(i)
-
-		// Only hardfork-time pointer fields participate in the activation-time copy.
-		if srcField.Kind() != reflect.Ptr {
+	for _, fork := range AllHardforks {
+		srcTime := fork.Time(src)
+		if srcTime == nil {
 			continue
 		}
 
-		if srcField.IsNil() {
-			continue
-		}
-
-		// Get the corresponding field in the destination
-		dstField := dstVal.Field(i)
-
 		// Only copy if dest is nil
-		if !dstField.IsNil() {
+		if fork.Time(dst) != nil {
 			continue
 		}
 
-		srcValue := reflect.Indirect(srcField).Uint()
-		if srcValue < *superchainTime {
+		if uint64(*srcTime) < *superchainTime {
 			// No change if hardfork activated before SuperchainTime
 			continue
 		}
 
-		if srcValue > *genesisTime {
+		if uint64(*srcTime) > *genesisTime {
 			// Use src value if is after genesis
-			dstField.Set(srcField)
-		} else {
-			// Use zero if it is equal to or before genesis
-			ptrZero := reflect.ValueOf(NewHardforkTime(0))
-			dstField.Set(ptrZero)
+			fork.SetTime(dst, srcTime)
+		} else if !fork.Corrective {
+			// Use zero if it is equal to or before genesis. Corrective forks
+			// are left unset instead, since they change nothing for the chain.
+			fork.SetTime(dst, NewHardforkTime(0))
 		}
 	}
 
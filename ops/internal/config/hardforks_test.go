@@ -1,6 +1,8 @@
 package config
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/BurntSushi/toml"
@@ -97,6 +99,25 @@ func TestCopyHardforks_KeepKarstUpgradeGas(t *testing.T) {
 	})
 }
 
+func TestCopyHardforks_Corrective(t *testing.T) {
+	src := &Hardforks{
+		HoloceneTime:           NewHardforkTime(5),
+		PectraBlobScheduleTime: NewHardforkTime(5),
+	}
+	superchainTime := uint64(0)
+
+	// Chains launched after the Pectra blob schedule fix never need it.
+	genesisTime := uint64(10)
+	dest := &Hardforks{}
+	require.NoError(t, CopyHardforks(src, dest, &superchainTime, &genesisTime))
+	require.Equal(t, &Hardforks{HoloceneTime: NewHardforkTime(0)}, dest)
+
+	genesisTime = 1
+	dest = &Hardforks{}
+	require.NoError(t, CopyHardforks(src, dest, &superchainTime, &genesisTime))
+	require.Equal(t, src, dest)
+}
+
 func TestCopyHardforksActivationSemantics(t *testing.T) {
 	canyonAt := func(t uint64) *Hardforks {
 		return &Hardforks{
@@ -109,6 +130,13 @@ func TestCopyHardforksActivationSemantics(t *testing.T) {
 		}
 	}
 
+	// The Pectra blob schedule fix is corrective, so it is never zeroed.
+	blobScheduleAt := func(t uint64) *Hardforks {
+		return &Hardforks{
+			PectraBlobScheduleTime: NewHardforkTime(t),
+		}
+	}
+
 	genesisAt := func(t uint64) *uint64 {
 		h := uint64(t)
 		return &h
@@ -132,6 +160,12 @@ func TestCopyHardforksActivationSemantics(t *testing.T) {
 		{"src(after_zero_superchain_time)+dest(nil)=src", canyonAt(3), nilCanyon(), superchainTimeAt(0), genesisAt(0), canyonAt(3)},
 		{"src(before_superchain_time)+dest(nil)=dest", canyonAt(3), nilCanyon(), superchainTimeAt(10), genesisAt(0), nilCanyon()},
 		{"src(after_superchain_time_before_genesis)+dest(nil)=0", canyonAt(3), nilCanyon(), superchainTimeAt(1), genesisAt(4), canyonAt(0)},
+		{"src(at_genesis)+dest(nil)=0", canyonAt(4), nilCanyon(), superchainTimeAt(1), genesisAt(4), canyonAt(0)},
+		{"corrective_src(after_genesis)+dest(nil)=src", blobScheduleAt(5), &Hardforks{}, superchainTimeAt(1), genesisAt(4), blobScheduleAt(5)},
+		{"corrective_src(at_genesis)+dest(nil)=dest", blobScheduleAt(4), &Hardforks{}, superchainTimeAt(1), genesisAt(4), &Hardforks{}},
+		{"corrective_src(before_genesis)+dest(nil)=dest", blobScheduleAt(3), &Hardforks{}, superchainTimeAt(1), genesisAt(4), &Hardforks{}},
+		{"corrective_src(before_superchain_time)+dest(nil)=dest", blobScheduleAt(3), &Hardforks{}, superchainTimeAt(10), genesisAt(0), &Hardforks{}},
+		{"corrective_src+dest=dest", blobScheduleAt(5), blobScheduleAt(8), superchainTimeAt(1), genesisAt(4), blobScheduleAt(8)},
 	}
 
 	for _, tc := range testCases {
@@ -147,3 +181,128 @@ func TestCopyHardforksActivationSemantics(t *testing.T) {
 		})
 	}
 }
+
+// Every hardfork time in Hardforks must have exactly one catalogue entry, so that
+// a new fork cannot be added without wiring it through AllHardforks.
+func TestAllHardforks_CoversHardforks(t *testing.T) {
+	typ := reflect.TypeOf(Hardforks{})
+	hftPtr := reflect.TypeOf((*HardforkTime)(nil))
+
+	seen := make(map[string]bool)
+	for _, fork := range AllHardforks {
+		require.False(t, seen[fork.TOMLKey], "duplicate catalogue entry %s", fork.TOMLKey)
+		seen[fork.TOMLKey] = true
+		require.Equal(t, "L2Genesis"+fork.Name+"TimeOffset", fork.DeployConfigOffset)
+	}
+
+	var fields int
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Type != hftPtr {
+			continue
+		}
+		fields++
+
+		tomlKey := strings.Split(field.Tag.Get("toml"), ",")[0]
+		require.True(t, seen[tomlKey], "hardfork field %s has no catalogue entry", field.Name)
+
+		// The catalogue accessor must point at the field with the matching TOML key.
+		var h Hardforks
+		reflect.ValueOf(&h).Elem().Field(i).Set(reflect.ValueOf(NewHardforkTime(uint64(i + 1))))
+		for _, fork := range AllHardforks {
+			if fork.TOMLKey == tomlKey {
+				require.Equal(t, NewHardforkTime(uint64(i+1)), fork.Time(&h), fork.Name)
+			} else {
+				require.Nil(t, fork.Time(&h), fork.Name)
+			}
+		}
+	}
+	require.Equal(t, len(AllHardforks), fields)
+}
+
+func TestHardforks_ValidateOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		hfs     Hardforks
+		wantErr string
+	}{
+		{
+			name: "empty",
+			hfs:  Hardforks{},
+		},
+		{
+			name: "ordered",
+			hfs: Hardforks{
+				CanyonTime:   NewHardforkTime(0),
+				DeltaTime:    NewHardforkTime(0),
+				HoloceneTime: NewHardforkTime(10),
+				IsthmusTime:  NewHardforkTime(20),
+			},
+		},
+		{
+			name: "equal times",
+			hfs: Hardforks{
+				FjordTime:   NewHardforkTime(5),
+				GraniteTime: NewHardforkTime(5),
+			},
+		},
+		{
+			name: "unordered fork is ignored",
+			hfs: Hardforks{
+				HoloceneTime:           NewHardforkTime(20),
+				PectraBlobScheduleTime: NewHardforkTime(10),
+			},
+		},
+		{
+			name: "isthmus before holocene",
+			hfs: Hardforks{
+				HoloceneTime: NewHardforkTime(20),
+				IsthmusTime:  NewHardforkTime(10),
+			},
+			wantErr: "isthmus_time (10) is before holocene_time (20)",
+		},
+		{
+			name: "compares against last set fork",
+			hfs: Hardforks{
+				GraniteTime: NewHardforkTime(30),
+				JovianTime:  NewHardforkTime(20),
+			},
+			wantErr: "jovian_time (20) is before granite_time (30)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.hfs.ValidateOrder()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrHardforkOutOfOrder)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestHardforks_StatusAt(t *testing.T) {
+	hfs := &Hardforks{
+		CanyonTime:   NewHardforkTime(0),
+		HoloceneTime: NewHardforkTime(100),
+		IsthmusTime:  NewHardforkTime(200),
+	}
+
+	statuses := make(map[string]HardforkStatus)
+	for _, activation := range hfs.StatusAt(100) {
+		statuses[activation.Key] = activation.Status
+	}
+	require.Len(t, statuses, len(AllHardforks))
+	require.Equal(t, HardforkActive, statuses["canyon_time"])
+	require.Equal(t, HardforkActive, statuses["holocene_time"])
+	require.Equal(t, HardforkPending, statuses["isthmus_time"])
+	require.Equal(t, HardforkUnset, statuses["delta_time"])
+
+	var active []string
+	for _, fork := range hfs.ActiveAt(199) {
+		active = append(active, fork.Name)
+	}
+	require.Equal(t, []string{"Canyon", "Holocene"}, active)
+}
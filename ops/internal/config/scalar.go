@@ -0,0 +1,33 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var ErrInvalidScalar = errors.New("invalid scalar")
+
+// EcotoneScalars decodes Scalar. Unknown versions and non-zero padding are
+// rejected, since the L2 would not decode them the same way.
+func (s *SystemConfig) EcotoneScalars() (eth.EcotoneScalars, error) {
+	if err := eth.CheckEcotoneL1SystemConfigScalar(s.Scalar); err != nil {
+		return eth.EcotoneScalars{}, fmt.Errorf("%w %s: %w", ErrInvalidScalar, s.Scalar, err)
+	}
+	return eth.DecodeScalar(s.Scalar)
+}
+
+// SetEcotoneScalars decodes Scalar and sets BaseFeeScalar and BlobBaseFeeScalar
+// from it.
+func (s *SystemConfig) SetEcotoneScalars() error {
+	scalars, err := s.EcotoneScalars()
+	if err != nil {
+		return err
+	}
+	baseFeeScalar := uint64(scalars.BaseFeeScalar)
+	blobBaseFeeScalar := uint64(scalars.BlobBaseFeeScalar)
+	s.BaseFeeScalar = &baseFeeScalar
+	s.BlobBaseFeeScalar = &blobBaseFeeScalar
+	return nil
+}
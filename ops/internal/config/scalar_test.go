@@ -0,0 +1,61 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemConfig_SetEcotoneScalars(t *testing.T) {
+	tests := []struct {
+		name              string
+		scalar            string
+		baseFeeScalar     uint64
+		blobBaseFeeScalar uint64
+		err               string
+	}{
+		{
+			name:          "bedrock",
+			scalar:        "0x00000000000000000000000000000000000000000000000000000000000a6fe0",
+			baseFeeScalar: 684_000,
+		},
+		{
+			name:              "ecotone",
+			scalar:            "0x010000000000000000000000000000000000000000000000000c5fc500000558",
+			baseFeeScalar:     1368,
+			blobBaseFeeScalar: 810_949,
+		},
+		{
+			name:   "bedrock with non-zero padding",
+			scalar: "0x00000000000000000000000000000000000000000000000000000001000a6fe0",
+			err:    "invalid scalar 0x00000000000000000000000000000000000000000000000000000001000a6fe0",
+		},
+		{
+			name:   "ecotone with non-zero padding",
+			scalar: "0x010000000000000000000000000000000000000000000001000c5fc500000558",
+			err:    "invalid scalar 0x010000000000000000000000000000000000000000000001000c5fc500000558",
+		},
+		{
+			name:   "unknown version",
+			scalar: "0x020000000000000000000000000000000000000000000000000c5fc500000558",
+			err:    "invalid scalar 0x020000000000000000000000000000000000000000000000000c5fc500000558",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sysCfg := SystemConfig{Scalar: common.HexToHash(tt.scalar)}
+			err := sysCfg.SetEcotoneScalars()
+			if tt.err != "" {
+				require.ErrorIs(t, err, ErrInvalidScalar)
+				require.ErrorContains(t, err, tt.err)
+				require.Nil(t, sysCfg.BaseFeeScalar)
+				require.Nil(t, sysCfg.BlobBaseFeeScalar)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.baseFeeScalar, *sysCfg.BaseFeeScalar)
+			require.Equal(t, tt.blobBaseFeeScalar, *sysCfg.BlobBaseFeeScalar)
+		})
+	}
+}
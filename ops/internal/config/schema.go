@@ -0,0 +1,283 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	SchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+	AddressPattern = "^0x[0-9a-fA-F]{40}$"
+	HashPattern    = "^0x[0-9a-fA-F]{64}$"
+)
+
+var (
+	checksummedAddressType = reflect.TypeOf(ChecksummedAddress{})
+	hashType               = reflect.TypeOf(common.Hash{})
+
+	addressRegexp = regexp.MustCompile(AddressPattern)
+	hashRegexp    = regexp.MustCompile(HashPattern)
+)
+
+// Schema is the subset of JSON Schema needed to describe the registry's TOML
+// configs. Schemas are generated from the config structs' toml tags, so they
+// always match what the Go tooling decodes.
+type Schema struct {
+	Dialect              string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *int64             `json:"minimum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+
+	// forbidden marks the boolean schema false, which matches nothing. It is
+	// used as additionalProperties to reject unknown keys.
+	forbidden bool
+}
+
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.forbidden {
+		return []byte("false"), nil
+	}
+	type schema Schema
+	return json.Marshal((*schema)(s))
+}
+
+func ChainSchema() *Schema {
+	return GenerateSchema("Chain", Chain{})
+}
+
+func StagedChainSchema() *Schema {
+	return GenerateSchema("StagedChain", StagedChain{})
+}
+
+func SuperchainDefinitionSchema() *Schema {
+	return GenerateSchema("SuperchainDefinition", SuperchainDefinition{})
+}
+
+// GenerateSchema generates a JSON Schema for the TOML representation of v.
+// Non-pointer fields without omitempty are required, and enum values are read
+// from `jsonschema:"enum=a,enum=b"` field tags.
+func GenerateSchema(title string, v any) *Schema {
+	s := schemaForType(reflect.TypeOf(v))
+	s.Dialect = SchemaDialect
+	s.Title = title
+	return s
+}
+
+func schemaForType(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case checksummedAddressType:
+		return &Schema{Type: "string", Pattern: AddressPattern}
+	case hashType:
+		return &Schema{Type: "string", Pattern: HashPattern}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero := int64(0)
+		return &Schema{Type: "integer", Minimum: &zero}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: schemaForType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaForType(t.Elem())}
+	case reflect.Struct:
+		s := &Schema{
+			Type:                 "object",
+			Properties:           make(map[string]*Schema),
+			AdditionalProperties: &Schema{forbidden: true},
+		}
+		addStructProperties(s, t)
+		return s
+	default:
+		panic(fmt.Sprintf("unsupported type in config schema: %s", t))
+	}
+}
+
+func addStructProperties(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("toml"), ",")
+		if name == "-" {
+			continue
+		}
+		// Untagged embedded structs are flattened by the TOML decoder.
+		if field.Anonymous && name == "" {
+			addStructProperties(s, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		prop := schemaForType(field.Type)
+		for _, opt := range strings.Split(field.Tag.Get("jsonschema"), ",") {
+			if value, ok := strings.CutPrefix(opt, "enum="); ok {
+				prop.Enum = append(prop.Enum, value)
+			}
+		}
+		s.Properties[name] = prop
+
+		if field.Type.Kind() != reflect.Pointer && !strings.Contains(opts, "omitempty") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// SchemaViolation describes a value that does not match a schema. Key is the
+// dotted TOML key path of the offending value.
+type SchemaViolation struct {
+	Key     string
+	Message string
+}
+
+// Validate checks decoded TOML data, as produced by decoding into a map[string]any,
+// against the schema.
+func (s *Schema) Validate(data any) []SchemaViolation {
+	var out []SchemaViolation
+	s.validate(nil, data, &out)
+	return out
+}
+
+func (s *Schema) validate(key []string, data any, out *[]SchemaViolation) {
+	report := func(key []string, format string, args ...any) {
+		*out = append(*out, SchemaViolation{
+			Key:     strings.Join(key, "."),
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := data.(map[string]any)
+		if !ok {
+			report(key, "expected a table, got %s", tomlTypeName(data))
+			return
+		}
+		for _, req := range s.Required {
+			if _, ok := obj[req]; !ok {
+				report(appendKey(key, req), "missing required key")
+			}
+		}
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			prop, ok := s.Properties[name]
+			if !ok {
+				prop = s.AdditionalProperties
+			}
+			if prop == nil {
+				continue
+			}
+			if prop.forbidden {
+				report(appendKey(key, name), "unknown key")
+				continue
+			}
+			prop.validate(appendKey(key, name), obj[name], out)
+		}
+	case "array":
+		var items []any
+		switch v := data.(type) {
+		case []any:
+			items = v
+		case []map[string]any:
+			for _, item := range v {
+				items = append(items, item)
+			}
+		default:
+			report(key, "expected an array, got %s", tomlTypeName(data))
+			return
+		}
+		for _, item := range items {
+			s.Items.validate(key, item, out)
+		}
+	case "string":
+		str, ok := data.(string)
+		if !ok {
+			report(key, "expected a string, got %s", tomlTypeName(data))
+			return
+		}
+		switch s.Pattern {
+		case "":
+		case AddressPattern:
+			if !addressRegexp.MatchString(str) {
+				report(key, "%q is not a valid address", str)
+			}
+		case HashPattern:
+			if !hashRegexp.MatchString(str) {
+				report(key, "%q is not a valid hash", str)
+			}
+		default:
+			if !regexp.MustCompile(s.Pattern).MatchString(str) {
+				report(key, "%q does not match pattern %s", str, s.Pattern)
+			}
+		}
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, str) {
+			report(key, "%q is not one of %s", str, strings.Join(s.Enum, ", "))
+		}
+	case "integer":
+		n, ok := data.(int64)
+		if !ok {
+			report(key, "expected an integer, got %s", tomlTypeName(data))
+			return
+		}
+		if s.Minimum != nil && n < *s.Minimum {
+			report(key, "%d is less than the minimum of %d", n, *s.Minimum)
+		}
+	case "boolean":
+		if _, ok := data.(bool); !ok {
+			report(key, "expected a boolean, got %s", tomlTypeName(data))
+		}
+	}
+}
+
+func appendKey(key []string, name string) []string {
+	return append(slices.Clone(key), name)
+}
+
+func tomlTypeName(data any) string {
+	switch data.(type) {
+	case map[string]any:
+		return "a table"
+	case []any, []map[string]any:
+		return "an array"
+	case string:
+		return "a string"
+	case int64:
+		return "an integer"
+	case float64:
+		return "a float"
+	case bool:
+		return "a boolean"
+	default:
+		return fmt.Sprintf("%T", data)
+	}
+}
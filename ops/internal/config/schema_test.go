@@ -0,0 +1,59 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainSchema(t *testing.T) {
+	schema := ChainSchema()
+	require.Equal(t, SchemaDialect, schema.Dialect)
+	require.Equal(t, "object", schema.Type)
+	require.Contains(t, schema.Required, "chain_id")
+	require.NotContains(t, schema.Required, "superchain_time")
+	require.NotContains(t, schema.Required, "gas_paying_token")
+
+	require.Equal(t, []string{"eth-da", "alt-da"}, schema.Properties["data_availability_type"].Enum)
+	require.Equal(t, AddressPattern, schema.Properties["batch_inbox_addr"].Pattern)
+	require.Equal(t, HashPattern, schema.Properties["genesis"].Properties["l2"].Properties["hash"].Pattern)
+	require.Equal(t, "integer", schema.Properties["hardforks"].Properties["holocene_time"].Type)
+
+	data, err := json.Marshal(schema.Properties["optimism"])
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"eip1559_elasticity": {"type": "integer", "minimum": 0},
+			"eip1559_denominator": {"type": "integer", "minimum": 0},
+			"eip1559_denominator_canyon": {"type": "integer", "minimum": 0}
+		},
+		"required": ["eip1559_elasticity", "eip1559_denominator", "eip1559_denominator_canyon"],
+		"additionalProperties": false
+	}`, string(data))
+}
+
+func TestStagedChainSchema(t *testing.T) {
+	schema := StagedChainSchema()
+
+	// Fields of the embedded Chain are flattened.
+	require.Contains(t, schema.Properties, "chain_id")
+	require.Contains(t, schema.Properties, "deployment_tx_hash")
+	require.NotContains(t, schema.Properties, "ShortName")
+	require.Contains(t, schema.Required, "superchain")
+}
+
+func TestSchemaValidate(t *testing.T) {
+	violations := SuperchainDefinitionSchema().Validate(map[string]any{
+		"name":                   "Test",
+		"superchain_config_addr": "0x1234",
+		"hardforks":              map[string]any{"holocen_time": int64(1)},
+		"l1":                     map[string]any{"chain_id": int64(-1), "public_rpc": "", "explorer": ""},
+	})
+	require.Equal(t, []SchemaViolation{
+		{Key: "hardforks.holocen_time", Message: "unknown key"},
+		{Key: "l1.chain_id", Message: "-1 is less than the minimum of 0"},
+		{Key: "superchain_config_addr", Message: `"0x1234" is not a valid address`},
+	}, violations)
+}
@@ -71,6 +71,7 @@ type SuperchainDefinition struct {
 	Name                   string              `toml:"name"`
 	SuperchainConfigAddr   *ChecksummedAddress `toml:"superchain_config_addr"`
 	OPContractsManagerAddr *ChecksummedAddress `toml:"op_contracts_manager_addr"`
+	SaferSafesAddr         *ChecksummedAddress `toml:"safer_safes_addr,omitempty"`
 	Hardforks              Hardforks           `toml:"hardforks"`
 	L1                     SuperchainL1        `toml:"l1"`
 }
@@ -0,0 +1,82 @@
+package fs
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	iofs "io/fs"
+	"os/exec"
+	"path"
+	"strings"
+)
+
+// ReadTarball reads every regular file of a tar archive into a MemFS. Gzipped
+// archives are detected and decompressed. Entries whose names escape the root
+// of the archive are rejected.
+func ReadTarball(r io.Reader) (*MemFS, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	var tr *tar.Reader
+	if bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+		defer gr.Close()
+		tr = tar.NewReader(gr)
+	} else {
+		tr = tar.NewReader(br)
+	}
+
+	out := NewMemFS()
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(strings.TrimPrefix(hdr.Name, "/"))
+		if !iofs.ValidPath(name) || name == "." {
+			return nil, fmt.Errorf("invalid file name in archive: %s", hdr.Name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from archive: %w", hdr.Name, err)
+		}
+		if err := out.WriteFile(name, data, hdr.FileInfo().Mode().Perm()); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// ReadGitRevision reads the tree of rev in the git repository at repoDir into a
+// MemFS. The files are read from the repository's objects with git archive, so
+// neither the working tree nor the index is touched.
+func ReadGitRevision(repoDir string, rev string) (*MemFS, error) {
+	if strings.HasPrefix(rev, "-") {
+		return nil, fmt.Errorf("invalid git revision: %s", rev)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "-C", repoDir, "archive", "--format=tar", rev)
+	cmd.Stderr = &stderr
+	data, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive git revision %s: %w: %s", rev, err, strings.TrimSpace(stderr.String()))
+	}
+	return ReadTarball(bytes.NewReader(data))
+}
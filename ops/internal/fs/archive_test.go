@@ -0,0 +1,91 @@
+package fs
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	iofs "io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTarball(t *testing.T) {
+	files := map[string]string{
+		"repo/a.txt":     "a",
+		"/repo/b/c.toml": "c",
+	}
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "repo/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	for _, name := range []string{"repo/a.txt", "/repo/b/c.toml"} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(files[name]))}))
+		_, err := tw.Write([]byte(files[name]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "repo/link", Typeflag: tar.TypeSymlink, Linkname: "a.txt"}))
+	require.NoError(t, tw.Close())
+
+	var gzBuf bytes.Buffer
+	gw := gzip.NewWriter(&gzBuf)
+	_, err := gw.Write(buf.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	for name, data := range map[string][]byte{"tar": buf.Bytes(), "tar.gz": gzBuf.Bytes()} {
+		t.Run(name, func(t *testing.T) {
+			fsys, err := ReadTarball(bytes.NewReader(data))
+			require.NoError(t, err)
+			require.Equal(t, []string{"repo/a.txt", "repo/b/c.toml"}, fsys.Files())
+			c, err := iofs.ReadFile(fsys, "repo/b/c.toml")
+			require.NoError(t, err)
+			require.Equal(t, "c", string(c))
+		})
+	}
+
+	t.Run("escaping name", func(t *testing.T) {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "../evil.txt", Typeflag: tar.TypeReg, Mode: 0o644}))
+		require.NoError(t, tw.Close())
+
+		_, err := ReadTarball(&buf)
+		require.ErrorContains(t, err, "invalid file name in archive")
+	})
+}
+
+func TestReadGitRevision(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "a"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a", "b.txt"), []byte("first"), 0o644))
+	git("add", "-A")
+	git("commit", "-q", "-m", "first")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a", "b.txt"), []byte("second"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.txt"), []byte("untracked"), 0o644))
+
+	fsys, err := ReadGitRevision(dir, "HEAD")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a/b.txt"}, fsys.Files())
+	data, err := iofs.ReadFile(fsys, "a/b.txt")
+	require.NoError(t, err)
+	require.Equal(t, "first", string(data))
+
+	_, err = ReadGitRevision(dir, "does-not-exist")
+	require.ErrorContains(t, err, "failed to archive git revision does-not-exist")
+	_, err = ReadGitRevision(dir, "--output=/tmp/evil")
+	require.ErrorContains(t, err, "invalid git revision")
+}
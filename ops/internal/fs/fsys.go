@@ -0,0 +1,153 @@
+package fs
+
+import (
+	"errors"
+	iofs "io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing/fstest"
+)
+
+// WriteFS is a file system that can be written as well as read. Like io/fs,
+// names are slash-separated and relative to the root of the file system.
+type WriteFS interface {
+	iofs.FS
+
+	// WriteFile replaces the contents of name, creating it and its parent
+	// directories if they do not exist. As with os.WriteFile, perm is only used
+	// if the file is created.
+	WriteFile(name string, data []byte, perm iofs.FileMode) error
+
+	// Remove removes the file name.
+	Remove(name string) error
+}
+
+// DirFS is a WriteFS rooted at a directory on disk. Files are written with
+// AtomicWrite, so readers never see a partially written file.
+type DirFS struct {
+	iofs.FS
+	dir string
+}
+
+func NewDirFS(dir string) *DirFS {
+	return &DirFS{
+		FS:  os.DirFS(dir),
+		dir: dir,
+	}
+}
+
+// Dir returns the directory the file system is rooted at.
+func (d *DirFS) Dir() string {
+	return d.dir
+}
+
+// Path returns the path on disk of name.
+func (d *DirFS) Path(name string) string {
+	return filepath.Join(d.dir, filepath.FromSlash(name))
+}
+
+func (d *DirFS) WriteFile(name string, data []byte, perm iofs.FileMode) error {
+	if !iofs.ValidPath(name) {
+		return &iofs.PathError{Op: "write", Path: name, Err: iofs.ErrInvalid}
+	}
+
+	p := d.Path(name)
+	stat, err := os.Stat(p)
+	if err == nil {
+		perm = stat.Mode().Perm()
+	} else if !errors.Is(err, iofs.ErrNotExist) {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	return AtomicWrite(p, perm, data)
+}
+
+func (d *DirFS) Remove(name string) error {
+	if !iofs.ValidPath(name) {
+		return &iofs.PathError{Op: "remove", Path: name, Err: iofs.ErrInvalid}
+	}
+	return os.Remove(d.Path(name))
+}
+
+// MemFS is an in-memory WriteFS. Directories are implied by the files in them.
+// It is safe for concurrent use.
+type MemFS struct {
+	mtx   sync.RWMutex
+	files fstest.MapFS
+}
+
+func NewMemFS() *MemFS {
+	return &MemFS{
+		files: make(fstest.MapFS),
+	}
+}
+
+func (m *MemFS) Open(name string) (iofs.File, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.files.Open(name)
+}
+
+func (m *MemFS) WriteFile(name string, data []byte, perm iofs.FileMode) error {
+	if !iofs.ValidPath(name) || name == "." {
+		return &iofs.PathError{Op: "write", Path: name, Err: iofs.ErrInvalid}
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if existing, ok := m.files[name]; ok {
+		perm = existing.Mode.Perm()
+	}
+	// Files are replaced rather than modified, so open files keep their
+	// contents.
+	m.files[name] = &fstest.MapFile{
+		Data: slices.Clone(data),
+		Mode: perm,
+	}
+	return nil
+}
+
+func (m *MemFS) Remove(name string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if _, ok := m.files[name]; !ok {
+		return &iofs.PathError{Op: "remove", Path: name, Err: iofs.ErrNotExist}
+	}
+	delete(m.files, name)
+	return nil
+}
+
+// Files returns the names of every file, sorted.
+func (m *MemFS) Files() []string {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	names := make([]string, 0, len(m.files))
+	for name := range m.files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Sub returns a MemFS of the files under dir. Unlike io/fs.Sub, the result can
+// be written to, and is independent of m.
+func (m *MemFS) Sub(dir string) *MemFS {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	out := NewMemFS()
+	dir = path.Clean(dir)
+	for name, file := range m.files {
+		if dir == "." {
+			out.files[name] = file
+		} else if rel, ok := strings.CutPrefix(name, dir+"/"); ok {
+			out.files[rel] = file
+		}
+	}
+	return out
+}
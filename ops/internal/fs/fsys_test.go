@@ -0,0 +1,71 @@
+package fs
+
+import (
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirFS(t *testing.T) {
+	dir := t.TempDir()
+	fsys := NewDirFS(dir)
+	require.Equal(t, filepath.Join(dir, "a", "b.txt"), fsys.Path("a/b.txt"))
+
+	require.NoError(t, fsys.WriteFile("a/b.txt", []byte("hello"), 0o755))
+	data, err := os.ReadFile(filepath.Join(dir, "a", "b.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(data))
+
+	// Existing files keep their mode.
+	require.NoError(t, fsys.WriteFile("a/b.txt", []byte("world"), 0o644))
+	stat, err := os.Stat(filepath.Join(dir, "a", "b.txt"))
+	require.NoError(t, err)
+	require.Equal(t, iofs.FileMode(0o755), stat.Mode().Perm())
+	data, err = iofs.ReadFile(fsys, "a/b.txt")
+	require.NoError(t, err)
+	require.Equal(t, "world", string(data))
+
+	require.ErrorIs(t, fsys.WriteFile("../escape.txt", nil, 0o644), iofs.ErrInvalid)
+
+	require.NoError(t, fsys.Remove("a/b.txt"))
+	require.NoFileExists(t, filepath.Join(dir, "a", "b.txt"))
+	require.ErrorIs(t, fsys.Remove("a/b.txt"), iofs.ErrNotExist)
+}
+
+func TestMemFS(t *testing.T) {
+	fsys := NewMemFS()
+	require.NoError(t, fsys.WriteFile("a/b.txt", []byte("hello"), 0o755))
+	require.NoError(t, fsys.WriteFile("a/c/d.txt", []byte("nested"), 0o644))
+	require.NoError(t, fsys.WriteFile("e.txt", []byte("top"), 0o644))
+	require.NoError(t, fstest.TestFS(fsys, "a/b.txt", "a/c/d.txt", "e.txt"))
+	require.Equal(t, []string{"a/b.txt", "a/c/d.txt", "e.txt"}, fsys.Files())
+
+	// Existing files keep their mode, and the written data is copied.
+	data := []byte("world")
+	require.NoError(t, fsys.WriteFile("a/b.txt", data, 0o644))
+	data[0] = 'W'
+	read, err := iofs.ReadFile(fsys, "a/b.txt")
+	require.NoError(t, err)
+	require.Equal(t, "world", string(read))
+	stat, err := iofs.Stat(fsys, "a/b.txt")
+	require.NoError(t, err)
+	require.Equal(t, iofs.FileMode(0o755), stat.Mode().Perm())
+
+	require.ErrorIs(t, fsys.WriteFile("../escape.txt", nil, 0o644), iofs.ErrInvalid)
+	require.ErrorIs(t, fsys.WriteFile(".", nil, 0o644), iofs.ErrInvalid)
+
+	// Sub is independent of the file system it was created from.
+	sub := fsys.Sub("a")
+	require.Equal(t, []string{"b.txt", "c/d.txt"}, sub.Files())
+	require.NoError(t, sub.WriteFile("f.txt", nil, 0o644))
+	require.Equal(t, []string{"a/b.txt", "a/c/d.txt", "e.txt"}, fsys.Files())
+	require.Equal(t, fsys.Files(), fsys.Sub(".").Files())
+
+	require.NoError(t, fsys.Remove("e.txt"))
+	require.ErrorIs(t, fsys.Remove("e.txt"), iofs.ErrNotExist)
+	require.Equal(t, []string{"a/b.txt", "a/c/d.txt"}, fsys.Files())
+}
@@ -0,0 +1,159 @@
+package fs
+
+import (
+	"cmp"
+	"errors"
+	"io"
+	iofs "io/fs"
+	"path"
+	"slices"
+)
+
+// OverlayFS is a read-only union of file systems. A file is read from the last
+// layer that contains it, and directories list the entries of every layer.
+type OverlayFS struct {
+	layers []iofs.FS
+}
+
+// NewOverlayFS returns an OverlayFS of base with overlays merged on top of it,
+// in order.
+func NewOverlayFS(base iofs.FS, overlays ...iofs.FS) *OverlayFS {
+	return &OverlayFS{
+		layers: append([]iofs.FS{base}, overlays...),
+	}
+}
+
+// Layers returns the layers of the overlay, starting with the base.
+func (o *OverlayFS) Layers() []iofs.FS {
+	return slices.Clone(o.layers)
+}
+
+// Layer returns the index of the layer that name is read from, or -1 if no
+// layer contains it.
+func (o *OverlayFS) Layer(name string) int {
+	for i, layer := range slices.Backward(o.layers) {
+		if _, err := iofs.Stat(layer, name); err == nil {
+			return i
+		}
+	}
+	return -1
+}
+
+// Path returns the path on disk of name if the layer it is read from is on
+// disk, and name otherwise.
+func (o *OverlayFS) Path(name string) string {
+	i := o.Layer(name)
+	if i < 0 {
+		return name
+	}
+	if dir, ok := o.layers[i].(interface{ Path(string) string }); ok {
+		return dir.Path(name)
+	}
+	return name
+}
+
+func (o *OverlayFS) Open(name string) (iofs.File, error) {
+	if !iofs.ValidPath(name) {
+		return nil, &iofs.PathError{Op: "open", Path: name, Err: iofs.ErrInvalid}
+	}
+
+	var info iofs.FileInfo
+	var dirs []iofs.FS
+	for _, layer := range slices.Backward(o.layers) {
+		f, err := layer.Open(name)
+		if errors.Is(err, iofs.ErrNotExist) {
+			if hidesParent(layer, name) {
+				break
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		stat, err := f.Stat()
+		if err != nil || !stat.IsDir() {
+			// A file hides the directories of the layers below it.
+			if len(dirs) == 0 {
+				return f, err
+			}
+			f.Close()
+			break
+		}
+		f.Close()
+		if info == nil {
+			info = stat
+		}
+		dirs = append(dirs, layer)
+	}
+
+	if info == nil {
+		return nil, &iofs.PathError{Op: "open", Path: name, Err: iofs.ErrNotExist}
+	}
+
+	entries := make(map[string]iofs.DirEntry)
+	for _, layer := range slices.Backward(dirs) {
+		layerEntries, err := iofs.ReadDir(layer, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range layerEntries {
+			entries[entry.Name()] = entry
+		}
+	}
+	return newMergedDir(info, entries), nil
+}
+
+// hidesParent returns whether one of the parent directories of name is a file in
+// fsys, which hides name in the layers below it.
+func hidesParent(fsys iofs.FS, name string) bool {
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		if stat, err := iofs.Stat(fsys, dir); err == nil {
+			return !stat.IsDir()
+		}
+	}
+	return false
+}
+
+// mergedDir is a directory whose entries are merged from several file systems.
+type mergedDir struct {
+	info    iofs.FileInfo
+	entries []iofs.DirEntry
+	offset  int
+}
+
+func newMergedDir(info iofs.FileInfo, entries map[string]iofs.DirEntry) *mergedDir {
+	dir := &mergedDir{info: info}
+	for _, entry := range entries {
+		dir.entries = append(dir.entries, entry)
+	}
+	slices.SortFunc(dir.entries, func(a, b iofs.DirEntry) int {
+		return cmp.Compare(a.Name(), b.Name())
+	})
+	return dir
+}
+
+func (d *mergedDir) Stat() (iofs.FileInfo, error) {
+	return d.info, nil
+}
+
+func (d *mergedDir) Read([]byte) (int, error) {
+	return 0, &iofs.PathError{Op: "read", Path: d.info.Name(), Err: iofs.ErrInvalid}
+}
+
+func (d *mergedDir) Close() error {
+	return nil
+}
+
+func (d *mergedDir) ReadDir(n int) ([]iofs.DirEntry, error) {
+	remaining := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return remaining, nil
+	}
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(remaining))
+	d.offset += n
+	return remaining[:n], nil
+}
@@ -0,0 +1,50 @@
+package fs
+
+import (
+	iofs "io/fs"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverlayFS(t *testing.T) {
+	base := NewDirFS(t.TempDir())
+	require.NoError(t, base.WriteFile("a/b.txt", []byte("base"), 0o644))
+	require.NoError(t, base.WriteFile("a/c.txt", []byte("base"), 0o644))
+	require.NoError(t, base.WriteFile("d/e.txt", []byte("base"), 0o644))
+	overlay := NewMemFS()
+	require.NoError(t, overlay.WriteFile("a/b.txt", []byte("overlay"), 0o644))
+	require.NoError(t, overlay.WriteFile("a/f.txt", []byte("overlay"), 0o644))
+	require.NoError(t, overlay.WriteFile("d", []byte("file"), 0o644))
+
+	fsys := NewOverlayFS(base, overlay)
+	require.NoError(t, fstest.TestFS(fsys, "a/b.txt", "a/c.txt", "a/f.txt", "d"))
+
+	// Files of later layers win, and directories are merged.
+	data, err := iofs.ReadFile(fsys, "a/b.txt")
+	require.NoError(t, err)
+	require.Equal(t, "overlay", string(data))
+	data, err = iofs.ReadFile(fsys, "a/c.txt")
+	require.NoError(t, err)
+	require.Equal(t, "base", string(data))
+	entries, err := iofs.ReadDir(fsys, "a")
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	require.Equal(t, []string{"b.txt", "c.txt", "f.txt"}, names)
+
+	// A file hides a directory of an earlier layer.
+	_, err = iofs.Stat(fsys, "d/e.txt")
+	require.ErrorIs(t, err, iofs.ErrNotExist)
+
+	require.Equal(t, 1, fsys.Layer("a/b.txt"))
+	require.Equal(t, 0, fsys.Layer("a/c.txt"))
+	require.Equal(t, -1, fsys.Layer("missing.txt"))
+	require.Equal(t, filepath.Join(base.Dir(), "a", "c.txt"), fsys.Path("a/c.txt"))
+	require.Equal(t, "a/b.txt", fsys.Path("a/b.txt"))
+	require.Len(t, fsys.Layers(), 2)
+}
@@ -0,0 +1,406 @@
+package fs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// TxDir is the directory, relative to the root of a transaction, holding the
+// journal and file contents of a transaction while it is committed.
+const TxDir = ".ops-tx"
+
+const txJournalFile = "journal.json"
+
+// ErrTxPending is returned when a transaction is started while the journal of
+// a previous one remains. The previous transaction must be resumed with
+// ResumeTx or reverted with RevertTx first.
+var ErrTxPending = errors.New("a previous transaction did not finish")
+
+// Tx stages writes and removals of files under a directory, and applies them
+// together on Commit. Until then nothing is written to disk, but reads through
+// the Tx see the staged changes.
+//
+// Commit copies the new contents and backups of the original contents of every
+// file into TxDir, then writes a journal before touching any file. If applying
+// the changes fails, they are reverted from the backups. If the process dies
+// while applying them, the journal remains and the transaction can be resumed
+// or reverted later.
+type Tx struct {
+	root    *DirFS
+	mtx     sync.RWMutex
+	writes  *MemFS
+	removes map[string]bool
+}
+
+// TxOp is a change to a single file in a transaction's journal. Staged and
+// Backup are names in TxDir; Backup is empty if the file did not exist.
+type TxOp struct {
+	Name       string        `json:"name"`
+	Remove     bool          `json:"remove,omitempty"`
+	Staged     string        `json:"staged,omitempty"`
+	Perm       iofs.FileMode `json:"perm,omitempty"`
+	Backup     string        `json:"backup,omitempty"`
+	BackupPerm iofs.FileMode `json:"backupPerm,omitempty"`
+}
+
+type txJournal struct {
+	Ops []TxOp `json:"ops"`
+}
+
+// BeginTx starts a transaction on the files under root. It fails with
+// ErrTxPending if a previous transaction on root did not finish.
+func BeginTx(root string) (*Tx, error) {
+	pending, err := PendingTx(root)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, fmt.Errorf("%w: resume or revert it first", ErrTxPending)
+	}
+
+	return &Tx{
+		root:    NewDirFS(root),
+		writes:  NewMemFS(),
+		removes: make(map[string]bool),
+	}, nil
+}
+
+// Dir returns the directory the transaction is rooted at.
+func (t *Tx) Dir() string {
+	return t.root.Dir()
+}
+
+// Path returns the path on disk of name.
+func (t *Tx) Path(name string) string {
+	return t.root.Path(name)
+}
+
+func (t *Tx) WriteFile(name string, data []byte, perm iofs.FileMode) error {
+	if !iofs.ValidPath(name) || name == "." || isTxName(name) {
+		return &iofs.PathError{Op: "write", Path: name, Err: iofs.ErrInvalid}
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if _, err := iofs.Stat(t.writes, name); err != nil && !t.removes[name] {
+		stat, err := os.Stat(t.root.Path(name))
+		if err == nil {
+			perm = stat.Mode().Perm()
+		} else if !errors.Is(err, iofs.ErrNotExist) {
+			return err
+		}
+	}
+	delete(t.removes, name)
+	return t.writes.WriteFile(name, data, perm)
+}
+
+func (t *Tx) Remove(name string) error {
+	if !iofs.ValidPath(name) || isTxName(name) {
+		return &iofs.PathError{Op: "remove", Path: name, Err: iofs.ErrInvalid}
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.removes[name] {
+		return &iofs.PathError{Op: "remove", Path: name, Err: iofs.ErrNotExist}
+	}
+	staged := t.writes.Remove(name) == nil
+	if !staged {
+		stat, err := os.Stat(t.root.Path(name))
+		if err != nil {
+			return err
+		}
+		if stat.IsDir() {
+			return &iofs.PathError{Op: "remove", Path: name, Err: iofs.ErrInvalid}
+		}
+	}
+	t.removes[name] = true
+	return nil
+}
+
+// Open opens name with the staged changes applied. Directories list the files
+// on disk and the staged files in them, without the removed files.
+func (t *Tx) Open(name string) (iofs.File, error) {
+	if !iofs.ValidPath(name) {
+		return nil, &iofs.PathError{Op: "open", Path: name, Err: iofs.ErrInvalid}
+	}
+
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	if t.removes[name] {
+		return nil, &iofs.PathError{Op: "open", Path: name, Err: iofs.ErrNotExist}
+	}
+
+	var info iofs.FileInfo
+	if f, err := t.writes.Open(name); err == nil {
+		stat, err := f.Stat()
+		if err != nil || !stat.IsDir() {
+			return f, err
+		}
+		info = stat
+		f.Close()
+	}
+
+	if f, err := t.root.Open(name); err == nil {
+		stat, err := f.Stat()
+		if err != nil || !stat.IsDir() {
+			return f, err
+		}
+		info = stat
+		f.Close()
+	} else if !errors.Is(err, iofs.ErrNotExist) {
+		return nil, err
+	}
+
+	if info == nil {
+		return nil, &iofs.PathError{Op: "open", Path: name, Err: iofs.ErrNotExist}
+	}
+	return t.openDir(name, info)
+}
+
+func (t *Tx) openDir(name string, info iofs.FileInfo) (iofs.File, error) {
+	entries := make(map[string]iofs.DirEntry)
+	diskEntries, err := iofs.ReadDir(t.root, name)
+	if err != nil && !errors.Is(err, iofs.ErrNotExist) {
+		return nil, err
+	}
+	for _, entry := range diskEntries {
+		if !t.removes[path.Join(name, entry.Name())] {
+			entries[entry.Name()] = entry
+		}
+	}
+	stagedEntries, err := iofs.ReadDir(t.writes, name)
+	if err != nil && !errors.Is(err, iofs.ErrNotExist) {
+		return nil, err
+	}
+	for _, entry := range stagedEntries {
+		entries[entry.Name()] = entry
+	}
+
+	return newMergedDir(info, entries), nil
+}
+
+// Commit applies the staged changes. On failure, the changes that were applied
+// are reverted. A transaction with no changes commits without touching the
+// disk. The Tx can be reused for a new set of changes afterwards.
+func (t *Tx) Commit() error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	names := t.writes.Files()
+	for name := range t.removes {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	slices.Sort(names)
+
+	root := t.root.Dir()
+	pending, err := PendingTx(root)
+	if err != nil {
+		return err
+	}
+	if pending {
+		return fmt.Errorf("%w: resume or revert it first", ErrTxPending)
+	}
+
+	journal, err := t.prepare(names)
+	if err != nil {
+		return errors.Join(fmt.Errorf("failed to prepare transaction: %w", err), os.RemoveAll(filepath.Join(root, TxDir)))
+	}
+
+	if err := applyJournal(root, journal); err != nil {
+		if revertErr := revertJournal(root, journal); revertErr != nil {
+			return fmt.Errorf("failed to commit transaction: %w; failed to revert it, the journal in %s remains: %w", err, TxDir, revertErr)
+		}
+		return errors.Join(fmt.Errorf("failed to commit transaction, reverted it: %w", err), os.RemoveAll(filepath.Join(root, TxDir)))
+	}
+
+	t.writes = NewMemFS()
+	t.removes = make(map[string]bool)
+	if err := os.RemoveAll(filepath.Join(root, TxDir)); err != nil {
+		return fmt.Errorf("failed to remove transaction journal: %w", err)
+	}
+	return nil
+}
+
+// prepare copies the new and original contents of every file into TxDir and
+// writes the journal. Nothing outside of TxDir is modified.
+func (t *Tx) prepare(names []string) (*txJournal, error) {
+	txDir := filepath.Join(t.root.Dir(), TxDir)
+	if err := os.RemoveAll(txDir); err != nil {
+		return nil, fmt.Errorf("failed to clean transaction dir: %w", err)
+	}
+	if err := os.MkdirAll(txDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create transaction dir: %w", err)
+	}
+
+	journal := new(txJournal)
+	for i, name := range names {
+		op := TxOp{Name: name}
+
+		original, err := os.ReadFile(t.root.Path(name))
+		if err == nil {
+			stat, err := os.Stat(t.root.Path(name))
+			if err != nil {
+				return nil, err
+			}
+			op.Backup = fmt.Sprintf("backup-%d", i)
+			op.BackupPerm = stat.Mode().Perm()
+			if err := AtomicWrite(filepath.Join(txDir, op.Backup), 0o644, original); err != nil {
+				return nil, fmt.Errorf("failed to back up %s: %w", name, err)
+			}
+		} else if !errors.Is(err, iofs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+
+		if t.removes[name] {
+			op.Remove = true
+		} else {
+			data, err := iofs.ReadFile(t.writes, name)
+			if err != nil {
+				return nil, err
+			}
+			stat, err := iofs.Stat(t.writes, name)
+			if err != nil {
+				return nil, err
+			}
+			op.Staged = fmt.Sprintf("staged-%d", i)
+			op.Perm = stat.Mode().Perm()
+			if err := AtomicWrite(filepath.Join(txDir, op.Staged), 0o644, data); err != nil {
+				return nil, fmt.Errorf("failed to stage %s: %w", name, err)
+			}
+		}
+		journal.Ops = append(journal.Ops, op)
+	}
+
+	data, err := json.MarshalIndent(journal, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal journal: %w", err)
+	}
+	if err := AtomicWrite(filepath.Join(txDir, txJournalFile), 0o644, data); err != nil {
+		return nil, fmt.Errorf("failed to write journal: %w", err)
+	}
+	return journal, nil
+}
+
+// PendingTx returns whether a transaction on root did not finish, and left a
+// journal behind.
+func PendingTx(root string) (bool, error) {
+	return FileExists(filepath.Join(root, TxDir, txJournalFile))
+}
+
+// ResumeTx applies the changes of the unfinished transaction on root.
+func ResumeTx(root string) ([]TxOp, error) {
+	journal, err := readJournal(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyJournal(root, journal); err != nil {
+		return nil, fmt.Errorf("failed to resume transaction: %w", err)
+	}
+	if err := os.RemoveAll(filepath.Join(root, TxDir)); err != nil {
+		return nil, fmt.Errorf("failed to remove transaction journal: %w", err)
+	}
+	return journal.Ops, nil
+}
+
+// RevertTx restores the files changed by the unfinished transaction on root to
+// their contents before it.
+func RevertTx(root string) ([]TxOp, error) {
+	journal, err := readJournal(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := revertJournal(root, journal); err != nil {
+		return nil, fmt.Errorf("failed to revert transaction: %w", err)
+	}
+	if err := os.RemoveAll(filepath.Join(root, TxDir)); err != nil {
+		return nil, fmt.Errorf("failed to remove transaction journal: %w", err)
+	}
+	return journal.Ops, nil
+}
+
+func readJournal(root string) (*txJournal, error) {
+	data, err := os.ReadFile(filepath.Join(root, TxDir, txJournalFile))
+	if errors.Is(err, iofs.ErrNotExist) {
+		return nil, fmt.Errorf("no unfinished transaction in %s", root)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	journal := new(txJournal)
+	if err := json.Unmarshal(data, journal); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal journal: %w", err)
+	}
+	for _, op := range journal.Ops {
+		if !iofs.ValidPath(op.Name) || isTxName(op.Name) {
+			return nil, fmt.Errorf("invalid file name in journal: %s", op.Name)
+		}
+	}
+	return journal, nil
+}
+
+// applyJournal applies every op of journal. It can be run repeatedly, so a
+// transaction that failed while being applied can be resumed.
+func applyJournal(root string, journal *txJournal) error {
+	dir := NewDirFS(root)
+	for _, op := range journal.Ops {
+		if op.Remove {
+			if err := os.Remove(dir.Path(op.Name)); err != nil && !errors.Is(err, iofs.ErrNotExist) {
+				return fmt.Errorf("failed to remove %s: %w", op.Name, err)
+			}
+			continue
+		}
+		if err := copyFromTxDir(dir, op.Staged, op.Name, op.Perm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func revertJournal(root string, journal *txJournal) error {
+	dir := NewDirFS(root)
+	var errs []error
+	for _, op := range slices.Backward(journal.Ops) {
+		if op.Backup == "" {
+			if err := os.Remove(dir.Path(op.Name)); err != nil && !errors.Is(err, iofs.ErrNotExist) {
+				errs = append(errs, fmt.Errorf("failed to remove %s: %w", op.Name, err))
+			}
+			continue
+		}
+		if err := copyFromTxDir(dir, op.Backup, op.Name, op.BackupPerm); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func copyFromTxDir(dir *DirFS, src string, name string, perm iofs.FileMode) error {
+	data, err := os.ReadFile(filepath.Join(dir.Dir(), TxDir, src))
+	if err != nil {
+		return fmt.Errorf("failed to read contents of %s: %w", name, err)
+	}
+	p := dir.Path(name)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory of %s: %w", name, err)
+	}
+	if err := AtomicWrite(p, perm, data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+func isTxName(name string) bool {
+	return name == TxDir || strings.HasPrefix(name, TxDir+"/")
+}
@@ -0,0 +1,164 @@
+package fs
+
+import (
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupTxRoot(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "a.txt"), []byte("a"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "dir"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "dir", "b.txt"), []byte("b"), 0o644))
+	return root
+}
+
+func requireFile(t *testing.T, root string, name string, data string) {
+	t.Helper()
+
+	actual, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+	require.NoError(t, err)
+	require.Equal(t, data, string(actual))
+}
+
+func TestTx(t *testing.T) {
+	root := setupTxRoot(t)
+	tx, err := BeginTx(root)
+	require.NoError(t, err)
+
+	require.NoError(t, tx.WriteFile("a.txt", []byte("A"), 0o644))
+	require.NoError(t, tx.WriteFile("new/c.txt", []byte("c"), 0o644))
+	require.NoError(t, tx.Remove("dir/b.txt"))
+	require.ErrorIs(t, tx.Remove("dir/b.txt"), iofs.ErrNotExist)
+	require.ErrorIs(t, tx.Remove("missing.txt"), iofs.ErrNotExist)
+	require.ErrorIs(t, tx.WriteFile(TxDir+"/journal.json", nil, 0o644), iofs.ErrInvalid)
+
+	// Reads see the staged changes, the disk does not.
+	require.NoError(t, fstest.TestFS(tx, "a.txt", "new/c.txt"))
+	data, err := iofs.ReadFile(tx, "a.txt")
+	require.NoError(t, err)
+	require.Equal(t, "A", string(data))
+	_, err = iofs.Stat(tx, "dir/b.txt")
+	require.ErrorIs(t, err, iofs.ErrNotExist)
+	entries, err := iofs.ReadDir(tx, ".")
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	require.Equal(t, []string{"a.txt", "dir", "new"}, names)
+	requireFile(t, root, "a.txt", "a")
+	requireFile(t, root, "dir/b.txt", "b")
+	require.NoDirExists(t, filepath.Join(root, "new"))
+
+	require.NoError(t, tx.Commit())
+	requireFile(t, root, "a.txt", "A")
+	requireFile(t, root, "new/c.txt", "c")
+	require.NoFileExists(t, filepath.Join(root, "dir", "b.txt"))
+	require.NoDirExists(t, filepath.Join(root, TxDir))
+	stat, err := os.Stat(filepath.Join(root, "a.txt"))
+	require.NoError(t, err)
+	require.Equal(t, iofs.FileMode(0o755), stat.Mode().Perm())
+
+	// The Tx can be reused, and committing no changes is a no-op.
+	require.NoError(t, tx.Commit())
+	require.NoError(t, tx.WriteFile("dir/b.txt", []byte("B"), 0o644))
+	require.NoError(t, tx.Commit())
+	requireFile(t, root, "dir/b.txt", "B")
+}
+
+func TestTxCommitFailure(t *testing.T) {
+	t.Run("prepare", func(t *testing.T) {
+		root := setupTxRoot(t)
+		tx, err := BeginTx(root)
+		require.NoError(t, err)
+		require.NoError(t, tx.WriteFile("a.txt", []byte("A"), 0o644))
+		require.NoError(t, tx.WriteFile("z/c.txt", []byte("c"), 0o644))
+
+		// z is created as a file after staging, so z/c.txt can't be written.
+		require.NoError(t, os.WriteFile(filepath.Join(root, "z"), nil, 0o644))
+		require.ErrorContains(t, tx.Commit(), "failed to prepare transaction")
+		requireFile(t, root, "a.txt", "a")
+		require.NoDirExists(t, filepath.Join(root, TxDir))
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		root := setupTxRoot(t)
+		tx, err := BeginTx(root)
+		require.NoError(t, err)
+		require.NoError(t, tx.WriteFile("a.txt", []byte("A"), 0o644))
+		require.NoError(t, tx.Remove("dir/b.txt"))
+		require.NoError(t, tx.WriteFile("new/c.txt", []byte("c"), 0o644))
+
+		journal, err := tx.prepare([]string{"a.txt", "dir/b.txt", "new/c.txt"})
+		require.NoError(t, err)
+		require.NoError(t, os.Remove(filepath.Join(root, TxDir, journal.Ops[2].Staged)))
+		require.ErrorContains(t, applyJournal(root, journal), "failed to read contents of new/c.txt")
+		require.NoFileExists(t, filepath.Join(root, "dir", "b.txt"))
+
+		require.NoError(t, revertJournal(root, journal))
+		requireFile(t, root, "a.txt", "a")
+		requireFile(t, root, "dir/b.txt", "b")
+		require.NoFileExists(t, filepath.Join(root, "new", "c.txt"))
+	})
+}
+
+func TestTxJournal(t *testing.T) {
+	crash := func(t *testing.T) string {
+		root := setupTxRoot(t)
+		tx, err := BeginTx(root)
+		require.NoError(t, err)
+		require.NoError(t, tx.WriteFile("a.txt", []byte("A"), 0o644))
+		require.NoError(t, tx.WriteFile("new/c.txt", []byte("c"), 0o644))
+		require.NoError(t, tx.Remove("dir/b.txt"))
+
+		// Simulate a run that died after applying the first change.
+		journal, err := tx.prepare([]string{"a.txt", "dir/b.txt", "new/c.txt"})
+		require.NoError(t, err)
+		require.NoError(t, applyJournal(root, &txJournal{Ops: journal.Ops[:1]}))
+		requireFile(t, root, "a.txt", "A")
+
+		pending, err := PendingTx(root)
+		require.NoError(t, err)
+		require.True(t, pending)
+		_, err = BeginTx(root)
+		require.ErrorIs(t, err, ErrTxPending)
+		require.ErrorIs(t, tx.Commit(), ErrTxPending)
+		return root
+	}
+
+	t.Run("resume", func(t *testing.T) {
+		root := crash(t)
+		ops, err := ResumeTx(root)
+		require.NoError(t, err)
+		require.Len(t, ops, 3)
+		requireFile(t, root, "a.txt", "A")
+		requireFile(t, root, "new/c.txt", "c")
+		require.NoFileExists(t, filepath.Join(root, "dir", "b.txt"))
+		require.NoDirExists(t, filepath.Join(root, TxDir))
+	})
+
+	t.Run("revert", func(t *testing.T) {
+		root := crash(t)
+		ops, err := RevertTx(root)
+		require.NoError(t, err)
+		require.Len(t, ops, 3)
+		requireFile(t, root, "a.txt", "a")
+		requireFile(t, root, "dir/b.txt", "b")
+		require.NoFileExists(t, filepath.Join(root, "new", "c.txt"))
+		require.NoDirExists(t, filepath.Join(root, TxDir))
+		stat, err := os.Stat(filepath.Join(root, "a.txt"))
+		require.NoError(t, err)
+		require.Equal(t, iofs.FileMode(0o755), stat.Mode().Perm())
+
+		_, err = RevertTx(root)
+		require.ErrorContains(t, err, "no unfinished transaction")
+	})
+}
@@ -0,0 +1,126 @@
+// Package l1block pins onchain reads to a single L1 block, so that the data
+// read in one run is consistent, and the run can be reproduced later.
+package l1block
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var ErrBlockNotFound = errors.New("block not found")
+
+// Ref identifies the L1 block onchain data was read at.
+type Ref struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+}
+
+// BigNumber returns the number of the block for clients that take a *big.Int,
+// where nil reads at the latest block. It returns nil for a nil Ref.
+func (r *Ref) BigNumber() *big.Int {
+	if r == nil {
+		return nil
+	}
+	return new(big.Int).SetUint64(r.Number)
+}
+
+func (r Ref) String() string {
+	return fmt.Sprintf("%d (%s)", r.Number, r.Hash)
+}
+
+// Spec selects an L1 block: a decimal block number, a 0x-prefixed block hash,
+// or empty for the latest block.
+type Spec string
+
+func ParseSpec(s string) (Spec, error) {
+	spec := Spec(strings.TrimSpace(s))
+	if _, _, err := spec.parse(); err != nil {
+		return "", err
+	}
+	return spec, nil
+}
+
+func (s Spec) parse() (*uint64, *common.Hash, error) {
+	switch {
+	case s == "":
+		return nil, nil, nil
+	case strings.HasPrefix(string(s), "0x"):
+		data, err := hexutil.Decode(string(s))
+		if err != nil || len(data) != common.HashLength {
+			return nil, nil, fmt.Errorf("invalid block hash %q", s)
+		}
+		hash := common.BytesToHash(data)
+		return nil, &hash, nil
+	default:
+		number, err := strconv.ParseUint(string(s), 10, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid block number %q", s)
+		}
+		return &number, nil, nil
+	}
+}
+
+// ParseSpecs parses a block per superchain from values of the form
+// superchain=spec.
+func ParseSpecs(values []string) (map[config.Superchain]Spec, error) {
+	out := make(map[config.Superchain]Spec)
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		superchain, rawSpec, ok := strings.Cut(value, "=")
+		if !ok || superchain == "" {
+			return nil, fmt.Errorf("invalid L1 block %q, want superchain=number or superchain=hash", value)
+		}
+		if _, dup := out[superchain]; dup {
+			return nil, fmt.Errorf("L1 block of superchain %s is given more than once", superchain)
+		}
+		spec, err := ParseSpec(rawSpec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid L1 block of superchain %s: %w", superchain, err)
+		}
+		out[superchain] = spec
+	}
+	return out, nil
+}
+
+// Resolve looks up the block spec selects. It is called once at the start of
+// a run, and every read of the run is then made at the returned block.
+func Resolve(ctx context.Context, client *rpc.Client, spec Spec) (Ref, error) {
+	number, hash, err := spec.parse()
+	if err != nil {
+		return Ref{}, err
+	}
+
+	var block *struct {
+		Number hexutil.Uint64 `json:"number"`
+		Hash   common.Hash    `json:"hash"`
+	}
+	switch {
+	case hash != nil:
+		err = client.CallContext(ctx, &block, "eth_getBlockByHash", *hash, false)
+	case number != nil:
+		err = client.CallContext(ctx, &block, "eth_getBlockByNumber", hexutil.EncodeUint64(*number), false)
+	default:
+		err = client.CallContext(ctx, &block, "eth_getBlockByNumber", "latest", false)
+	}
+	if err != nil {
+		return Ref{}, fmt.Errorf("failed to get L1 block: %w", err)
+	}
+	if block == nil {
+		return Ref{}, fmt.Errorf("%w: %q", ErrBlockNotFound, spec)
+	}
+	return Ref{
+		Number: uint64(block.Number),
+		Hash:   block.Hash,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package l1block
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/testutil/mockrpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+const testHash = "0x1111111111111111111111111111111111111111111111111111111111111111"
+
+func TestParseSpecs(t *testing.T) {
+	specs, err := ParseSpecs([]string{"mainnet=123", "sepolia=" + testHash, "", "devnet="})
+	require.NoError(t, err)
+	require.Equal(t, map[string]Spec{
+		"mainnet": "123",
+		"sepolia": testHash,
+		"devnet":  "",
+	}, specs)
+
+	for _, tt := range []struct {
+		value string
+		err   string
+	}{
+		{"123", "want superchain=number or superchain=hash"},
+		{"=123", "want superchain=number or superchain=hash"},
+		{"mainnet=latest", "invalid block number"},
+		{"mainnet=0x1234", "invalid block hash"},
+	} {
+		_, err := ParseSpecs([]string{tt.value})
+		require.ErrorContains(t, err, tt.err, tt.value)
+	}
+
+	_, err = ParseSpecs([]string{"mainnet=1", "mainnet=2"})
+	require.ErrorContains(t, err, "more than once")
+}
+
+func TestResolve(t *testing.T) {
+	expectations := filepath.Join(t.TempDir(), "expectations.json")
+	require.NoError(t, os.WriteFile(expectations, []byte(`[
+  {"method": "eth_getBlockByNumber", "params": ["latest", false], "result": {"number": "0x7b", "hash": "`+testHash+`"}},
+  {"method": "eth_getBlockByNumber", "params": ["0x7b", false], "result": {"number": "0x7b", "hash": "`+testHash+`"}},
+  {"method": "eth_getBlockByHash", "params": ["`+testHash+`", false], "result": {"number": "0x7b", "hash": "`+testHash+`"}},
+  {"method": "eth_getBlockByNumber", "params": ["0x7c", false], "result": null}
+]`), 0o644))
+
+	lgr := testlog.Logger(t, slog.LevelInfo)
+	mockRPC := mockrpc.NewMockRPC(t, lgr, mockrpc.WithExpectationsFile(t, expectations))
+	client, err := rpc.Dial(mockRPC.Endpoint())
+	require.NoError(t, err)
+	defer client.Close()
+
+	want := Ref{Number: 123, Hash: common.HexToHash(testHash)}
+	for _, spec := range []Spec{"", "123", testHash} {
+		ref, err := Resolve(context.Background(), client, spec)
+		require.NoError(t, err)
+		require.Equal(t, want, ref)
+	}
+	_, err = Resolve(context.Background(), client, "124")
+	require.ErrorIs(t, err, ErrBlockNotFound)
+	mockRPC.AssertExpectations(t)
+}
@@ -0,0 +1,322 @@
+package l1block
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// blockParams are the methods that read at a block, and the index of their
+// block parameter.
+var blockParams = map[string]int{
+	"eth_call":                             1,
+	"eth_estimateGas":                      1,
+	"eth_createAccessList":                 1,
+	"eth_getBalance":                       1,
+	"eth_getCode":                          1,
+	"eth_getTransactionCount":              1,
+	"eth_getStorageAt":                     2,
+	"eth_getProof":                         2,
+	"eth_getBlockByNumber":                 0,
+	"eth_getBlockTransactionCountByNumber": 0,
+}
+
+// numberOnlyMethods only take a block number, rather than the block number or
+// hash of EIP-1898.
+var numberOnlyMethods = map[string]bool{
+	"eth_getBlockByNumber":                 true,
+	"eth_getBlockTransactionCountByNumber": true,
+}
+
+// pinnedTags are the block tags that are replaced with the pinned block.
+// Explicit block numbers and hashes, and "earliest", are left as they are.
+var pinnedTags = map[string]bool{
+	"latest":    true,
+	"pending":   true,
+	"safe":      true,
+	"finalized": true,
+}
+
+type rpcMessage struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// Proxy is a JSON-RPC endpoint on localhost that forwards requests to an
+// upstream L1 RPC, with reads at the latest block rewritten to read at a pinned
+// block. It pins tools that only take an RPC URL, like op-fetcher and
+// op-deployer. Only HTTP upstreams are supported.
+type Proxy struct {
+	upstream string
+	block    Ref
+	client   *http.Client
+	lis      net.Listener
+	srv      *http.Server
+}
+
+func NewProxy(upstream string, block Ref) (*Proxy, error) {
+	u, err := url.Parse(upstream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid upstream URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("cannot pin %s upstream, only HTTP is supported", u.Scheme)
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+	p := &Proxy{
+		upstream: upstream,
+		block:    block,
+		client:   &http.Client{Timeout: 5 * time.Minute},
+		lis:      lis,
+	}
+	p.srv = &http.Server{
+		Handler:           p,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		_ = p.srv.Serve(lis)
+	}()
+	return p, nil
+}
+
+// URL returns the URL to send requests to instead of the upstream.
+func (p *Proxy) URL() string {
+	return "http://" + p.lis.Addr().String()
+}
+
+// Block returns the block reads are pinned to.
+func (p *Proxy) Block() Ref {
+	return p.block
+}
+
+func (p *Proxy) Close() error {
+	err := p.srv.Close()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST requests are allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	batch := len(bytes.TrimSpace(body)) > 0 && bytes.TrimSpace(body)[0] == '['
+	var reqs []rpcMessage
+	if batch {
+		err = json.Unmarshal(body, &reqs)
+	} else {
+		reqs = make([]rpcMessage, 1)
+		err = json.Unmarshal(body, &reqs[0])
+	}
+	if err != nil {
+		writeJSON(w, rpcResponse{Version: "2.0", Error: &rpcError{Code: -32700, Message: err.Error()}})
+		return
+	}
+
+	var resps []json.RawMessage
+	var forward []rpcMessage
+	for _, req := range reqs {
+		var resp *rpcResponse
+		if req.Method == "eth_blockNumber" {
+			resp = &rpcResponse{Version: "2.0", ID: req.ID, Result: hexutil.Uint64(p.block.Number)}
+		} else if err := p.pin(&req); err != nil {
+			resp = &rpcResponse{Version: "2.0", ID: req.ID, Error: &rpcError{Code: -32602, Message: err.Error()}}
+		}
+		if resp == nil {
+			forward = append(forward, req)
+			continue
+		}
+		data, err := json.Marshal(resp)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resps = append(resps, data)
+	}
+
+	if len(forward) > 0 {
+		upstreamResps, err := p.forward(r.Context(), forward, batch)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		resps = append(resps, upstreamResps...)
+	}
+
+	if batch {
+		writeJSON(w, resps)
+	} else if len(resps) == 1 {
+		writeJSON(w, resps[0])
+	} else {
+		http.Error(w, "upstream returned no response", http.StatusBadGateway)
+	}
+}
+
+// pin rewrites the block parameter of req to the pinned block if it selects the
+// latest block, or a block relative to it.
+func (p *Proxy) pin(req *rpcMessage) error {
+	if req.Method == "eth_getLogs" {
+		return p.pinLogs(req)
+	}
+	idx, ok := blockParams[req.Method]
+	if !ok {
+		return nil
+	}
+
+	var params []json.RawMessage
+	if len(req.Params) > 0 {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return fmt.Errorf("invalid params: %w", err)
+		}
+	}
+	switch {
+	case len(params) < idx:
+		// Let the upstream report the missing parameters.
+		return nil
+	case len(params) == idx:
+		params = append(params, nil)
+	default:
+		var tag *string
+		if err := json.Unmarshal(params[idx], &tag); err != nil {
+			// Explicit block number or hash objects are left as they are.
+			return nil
+		}
+		if tag != nil && !pinnedTags[*tag] {
+			return nil
+		}
+	}
+
+	var pinned any = map[string]common.Hash{"blockHash": p.block.Hash}
+	if numberOnlyMethods[req.Method] || p.block.Hash == (common.Hash{}) {
+		pinned = hexutil.Uint64(p.block.Number)
+	}
+	data, err := json.Marshal(pinned)
+	if err != nil {
+		return err
+	}
+	params[idx] = data
+	req.Params, err = json.Marshal(params)
+	return err
+}
+
+// pinLogs rewrites the block range of an eth_getLogs filter like pin. Both
+// ends of the range default to the latest block, so missing ones are pinned
+// too. Filters for a single block hash are left as they are.
+func (p *Proxy) pinLogs(req *rpcMessage) error {
+	var params []map[string]json.RawMessage
+	if err := json.Unmarshal(req.Params, &params); err != nil || len(params) == 0 || params[0] == nil {
+		// Let the upstream report the invalid filter.
+		return nil
+	}
+	filter := params[0]
+	if _, ok := filter["blockHash"]; ok {
+		return nil
+	}
+
+	pinned, err := json.Marshal(hexutil.Uint64(p.block.Number))
+	if err != nil {
+		return err
+	}
+	for _, key := range []string{"fromBlock", "toBlock"} {
+		var tag *string
+		if raw, ok := filter[key]; ok {
+			if err := json.Unmarshal(raw, &tag); err != nil {
+				return fmt.Errorf("invalid %s: %w", key, err)
+			}
+		}
+		if tag == nil || pinnedTags[*tag] {
+			filter[key] = pinned
+		}
+	}
+	req.Params, err = json.Marshal(params)
+	return err
+}
+
+func (p *Proxy) forward(ctx context.Context, reqs []rpcMessage, batch bool) ([]json.RawMessage, error) {
+	var body []byte
+	var err error
+	if batch {
+		body, err = json.Marshal(reqs)
+	} else {
+		body, err = json.Marshal(reqs[0])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.upstream, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpResp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach upstream: %w", err)
+	}
+	defer httpResp.Body.Close()
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upstream response: %w", err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("upstream returned %s: %s", httpResp.Status, bytes.TrimSpace(respBody))
+	}
+
+	// Some upstreams answer a batch of one with a single response.
+	var resps []json.RawMessage
+	if trimmed := bytes.TrimSpace(respBody); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(trimmed, &resps)
+	} else {
+		resps = []json.RawMessage{trimmed}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid upstream response: %w", err)
+	}
+	return resps, nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,114 @@
+package l1block
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// echoUpstream answers every request with its params, so tests can see how the
+// proxy rewrote them.
+func echoUpstream(t *testing.T) (*httptest.Server, *[]string) {
+	var mtx sync.Mutex
+	var methods []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var reqs []rpcMessage
+		batch := body[0] == '['
+		if batch {
+			require.NoError(t, json.Unmarshal(body, &reqs))
+		} else {
+			reqs = make([]rpcMessage, 1)
+			require.NoError(t, json.Unmarshal(body, &reqs[0]))
+		}
+		var resps []rpcResponse
+		mtx.Lock()
+		for _, req := range reqs {
+			methods = append(methods, req.Method)
+			resps = append(resps, rpcResponse{Version: "2.0", ID: req.ID, Result: req.Params})
+		}
+		mtx.Unlock()
+		if batch {
+			writeJSON(w, resps)
+		} else {
+			writeJSON(w, resps[0])
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &methods
+}
+
+func TestProxy(t *testing.T) {
+	upstream, methods := echoUpstream(t)
+	block := Ref{Number: 123, Hash: common.HexToHash(testHash)}
+	proxy, err := NewProxy(upstream.URL, block)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, proxy.Close())
+	}()
+	require.Equal(t, block, proxy.Block())
+
+	client, err := rpc.Dial(proxy.URL())
+	require.NoError(t, err)
+	defer client.Close()
+	ctx := context.Background()
+
+	call := map[string]string{"to": "0x0000000000000000000000000000000000000001"}
+	pinnedHash := `{"blockHash":"` + testHash + `"}`
+	for _, tt := range []struct {
+		method string
+		params []any
+		want   string
+	}{
+		{"eth_call", []any{call, "latest"}, `[{"to":"0x0000000000000000000000000000000000000001"},` + pinnedHash + `]`},
+		{"eth_call", []any{call}, `[{"to":"0x0000000000000000000000000000000000000001"},` + pinnedHash + `]`},
+		{"eth_call", []any{call, "0x1"}, `[{"to":"0x0000000000000000000000000000000000000001"},"0x1"]`},
+		{"eth_getStorageAt", []any{call["to"], "0x0", "safe"}, `["0x0000000000000000000000000000000000000001","0x0",` + pinnedHash + `]`},
+		{"eth_getCode", []any{call["to"], "earliest"}, `["0x0000000000000000000000000000000000000001","earliest"]`},
+		{"eth_getBlockByNumber", []any{"latest", false}, `["0x7b",false]`},
+		{"eth_getTransactionByHash", []any{testHash}, `["` + testHash + `"]`},
+		{"eth_getLogs", []any{map[string]any{"address": call["to"]}}, `[{"address":"0x0000000000000000000000000000000000000001","fromBlock":"0x7b","toBlock":"0x7b"}]`},
+		{"eth_getLogs", []any{map[string]any{"fromBlock": "0x1", "toBlock": "latest"}}, `[{"fromBlock":"0x1","toBlock":"0x7b"}]`},
+		{"eth_getLogs", []any{map[string]any{"fromBlock": "earliest", "toBlock": "0x2"}}, `[{"fromBlock":"earliest","toBlock":"0x2"}]`},
+		{"eth_getLogs", []any{map[string]any{"blockHash": testHash}}, `[{"blockHash":"` + testHash + `"}]`},
+	} {
+		var got json.RawMessage
+		require.NoError(t, client.CallContext(ctx, &got, tt.method, tt.params...), tt.method)
+		require.JSONEq(t, tt.want, string(got), tt.method)
+	}
+
+	// eth_blockNumber is answered by the proxy, also within batches.
+	var number hexutil.Uint64
+	require.NoError(t, client.CallContext(ctx, &number, "eth_blockNumber"))
+	require.Equal(t, hexutil.Uint64(123), number)
+
+	var batchNumber hexutil.Uint64
+	var batchCall json.RawMessage
+	batch := []rpc.BatchElem{
+		{Method: "eth_blockNumber", Result: &batchNumber},
+		{Method: "eth_call", Args: []any{call, "latest"}, Result: &batchCall},
+	}
+	require.NoError(t, client.BatchCallContext(ctx, batch))
+	require.NoError(t, batch[0].Error)
+	require.NoError(t, batch[1].Error)
+	require.Equal(t, hexutil.Uint64(123), batchNumber)
+	require.JSONEq(t, `[{"to":"0x0000000000000000000000000000000000000001"},`+pinnedHash+`]`, string(batchCall))
+
+	require.NotContains(t, *methods, "eth_blockNumber")
+}
+
+func TestNewProxyUnsupportedUpstream(t *testing.T) {
+	_, err := NewProxy("ws://localhost:8546", Ref{Number: 1})
+	require.ErrorContains(t, err, "only HTTP is supported")
+}
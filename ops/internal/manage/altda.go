@@ -0,0 +1,157 @@
+package manage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/eth"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// MaxAltDAWindow is the longest alt-DA challenge or resolve window the registry
+// accepts: one week of 12 second L1 blocks.
+const MaxAltDAWindow = 7 * 24 * 60 * 60 / 12
+
+var ErrInvalidAltDAConfig = errors.New("invalid alt-DA config")
+
+// altDAConfigExemptions are chains that were registered as alt-da before their
+// alt_da table was required. They are still checked if they add one.
+var altDAConfigExemptions = map[uint64]bool{
+	252:   true, // fraxtal
+	291:   true, // orderly
+	957:   true, // lyra
+	42220: true, // celo
+}
+
+var (
+	challengeWindowABI = w3.MustNewFunc("challengeWindow()", "uint256")
+	resolveWindowABI   = w3.MustNewFunc("resolveWindow()", "uint256")
+)
+
+// ValidateAltDA checks that a chain's data availability type and alt-DA config
+// agree with each other and with its addresses. Every problem is reported.
+func ValidateAltDA(cfg *config.Chain) error {
+	var errs []error
+	report := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrInvalidAltDAConfig, fmt.Sprintf(format, args...)))
+	}
+
+	switch cfg.DataAvailabilityType {
+	case config.EthDADataAvailabilityType:
+		if cfg.AltDA != nil {
+			report("%s chain has an alt_da config", cfg.DataAvailabilityType)
+		}
+	case config.AltDADataAvailabilityType:
+		if cfg.AltDA == nil && !altDAConfigExemptions[cfg.ChainID] {
+			report("%s chain has no alt_da config", cfg.DataAvailabilityType)
+		}
+	default:
+		report("unknown data availability type %q", cfg.DataAvailabilityType)
+	}
+
+	if cfg.AltDA == nil {
+		return errors.Join(errs...)
+	}
+
+	altDA := cfg.AltDA
+	switch altDA.DaCommitmentType {
+	case config.KeccakCommitmentType:
+		if altDA.DaChallengeContractAddress == (config.ChecksummedAddress{}) {
+			report("%s requires a challenge contract", altDA.DaCommitmentType)
+		}
+	case config.GenericCommitmentType:
+	default:
+		report("unknown commitment type %q", altDA.DaCommitmentType)
+	}
+
+	if altDA.DaChallengeWindow == 0 || altDA.DaChallengeWindow > MaxAltDAWindow {
+		report("challenge window %d is not between 1 and %d", altDA.DaChallengeWindow, MaxAltDAWindow)
+	}
+	if altDA.DaResolveWindow == 0 || altDA.DaResolveWindow > MaxAltDAWindow {
+		report("resolve window %d is not between 1 and %d", altDA.DaResolveWindow, MaxAltDAWindow)
+	}
+
+	if addr := cfg.Addresses.DAChallengeAddress; addr != nil && *addr != altDA.DaChallengeContractAddress {
+		report("challenge contract %s does not match DAChallengeAddress %s", altDA.DaChallengeContractAddress, addr)
+	}
+
+	return errors.Join(errs...)
+}
+
+// CheckAltDAOnchain checks that the challenge contract of every chain using
+// Keccak commitments has code and the configured windows on L1. The contracts
+// are read with batched calls, so cfgs should all belong to the same
+// superchain.
+func CheckAltDAOnchain(ctx context.Context, client *w3.Client, cfgs []DiskChainConfig) error {
+	type result struct {
+		cfg             DiskChainConfig
+		code            []byte
+		challengeWindow big.Int
+		resolveWindow   big.Int
+	}
+
+	var results []*result
+	var codeCalls []w3types.RPCCaller
+	for _, cfg := range cfgs {
+		altDA := cfg.Config.AltDA
+		if altDA == nil || altDA.DaCommitmentType != config.KeccakCommitmentType {
+			continue
+		}
+
+		res := &result{cfg: cfg}
+		codeCalls = append(codeCalls, eth.Code(common.Address(altDA.DaChallengeContractAddress), nil).Returns(&res.code))
+		results = append(results, res)
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	if err := client.CallCtx(ctx, codeCalls...); err != nil {
+		return fmt.Errorf("failed to fetch alt-DA challenge contract code: %w", err)
+	}
+
+	// Calls to addresses without code return nothing and would fail to decode,
+	// so only query the windows of contracts that exist.
+	var windowCalls []w3types.RPCCaller
+	for _, res := range results {
+		if len(res.code) == 0 {
+			continue
+		}
+		addr := common.Address(res.cfg.Config.AltDA.DaChallengeContractAddress)
+		windowCalls = append(
+			windowCalls,
+			eth.CallFunc(addr, challengeWindowABI).Returns(&res.challengeWindow),
+			eth.CallFunc(addr, resolveWindowABI).Returns(&res.resolveWindow),
+		)
+	}
+	if len(windowCalls) > 0 {
+		if err := client.CallCtx(ctx, windowCalls...); err != nil {
+			return fmt.Errorf("failed to fetch alt-DA challenge windows: %w", err)
+		}
+	}
+
+	var errs []error
+	for _, res := range results {
+		altDA := res.cfg.Config.AltDA
+		report := func(format string, args ...any) {
+			errs = append(errs, fmt.Errorf("%w: %s: %s", ErrInvalidAltDAConfig, res.cfg.ShortName, fmt.Sprintf(format, args...)))
+		}
+
+		if len(res.code) == 0 {
+			report("challenge contract %s has no code", altDA.DaChallengeContractAddress)
+			continue
+		}
+		if !res.challengeWindow.IsUint64() || res.challengeWindow.Uint64() != altDA.DaChallengeWindow {
+			report("challenge window is %s onchain, but %d in config", &res.challengeWindow, altDA.DaChallengeWindow)
+		}
+		if !res.resolveWindow.IsUint64() || res.resolveWindow.Uint64() != altDA.DaResolveWindow {
+			report("resolve window is %s onchain, but %d in config", &res.resolveWindow, altDA.DaResolveWindow)
+		}
+	}
+	return errors.Join(errs...)
+}
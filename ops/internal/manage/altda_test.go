@@ -0,0 +1,147 @@
+package manage
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAltDA(t *testing.T) {
+	challengeAddr := config.ChecksummedAddress(common.HexToAddress("0x97A2dA87d3439b172e6DD027220e01c9Cb565B80"))
+	otherAddr := config.ChecksummedAddress(common.HexToAddress("0x16193e14197c10109F3e81b938153A04A2a00190"))
+
+	altDAChain := func() *config.Chain {
+		return &config.Chain{
+			ChainID:              690,
+			DataAvailabilityType: config.AltDADataAvailabilityType,
+			AltDA: &config.AltDA{
+				DaChallengeContractAddress: challengeAddr,
+				DaChallengeWindow:          3600,
+				DaResolveWindow:            3600,
+				DaCommitmentType:           config.KeccakCommitmentType,
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(cfg *config.Chain)
+		errs   []string
+	}{
+		{
+			name:   "valid alt-da chain",
+			mutate: func(cfg *config.Chain) {},
+		},
+		{
+			name: "valid eth-da chain",
+			mutate: func(cfg *config.Chain) {
+				cfg.DataAvailabilityType = config.EthDADataAvailabilityType
+				cfg.AltDA = nil
+			},
+		},
+		{
+			name: "generic commitment without challenge contract",
+			mutate: func(cfg *config.Chain) {
+				cfg.AltDA.DaCommitmentType = config.GenericCommitmentType
+				cfg.AltDA.DaChallengeContractAddress = config.ChecksummedAddress{}
+			},
+		},
+		{
+			name: "matching DAChallengeAddress",
+			mutate: func(cfg *config.Chain) {
+				cfg.Addresses.DAChallengeAddress = &challengeAddr
+			},
+		},
+		{
+			name: "eth-da chain with alt_da config",
+			mutate: func(cfg *config.Chain) {
+				cfg.DataAvailabilityType = config.EthDADataAvailabilityType
+			},
+			errs: []string{"eth-da chain has an alt_da config"},
+		},
+		{
+			name: "alt-da chain without alt_da config",
+			mutate: func(cfg *config.Chain) {
+				cfg.AltDA = nil
+			},
+			errs: []string{"alt-da chain has no alt_da config"},
+		},
+		{
+			name: "exempt alt-da chain without alt_da config",
+			mutate: func(cfg *config.Chain) {
+				cfg.ChainID = 252
+				cfg.AltDA = nil
+			},
+		},
+		{
+			name: "unknown data availability type",
+			mutate: func(cfg *config.Chain) {
+				cfg.DataAvailabilityType = "celestia"
+			},
+			errs: []string{`unknown data availability type "celestia"`},
+		},
+		{
+			name: "unknown commitment type",
+			mutate: func(cfg *config.Chain) {
+				cfg.AltDA.DaCommitmentType = "Sha256Commitment"
+			},
+			errs: []string{`unknown commitment type "Sha256Commitment"`},
+		},
+		{
+			name: "keccak commitment without challenge contract",
+			mutate: func(cfg *config.Chain) {
+				cfg.AltDA.DaChallengeContractAddress = config.ChecksummedAddress{}
+			},
+			errs: []string{"KeccakCommitment requires a challenge contract"},
+		},
+		{
+			name: "windows out of bounds",
+			mutate: func(cfg *config.Chain) {
+				cfg.AltDA.DaChallengeWindow = 0
+				cfg.AltDA.DaResolveWindow = MaxAltDAWindow + 1
+			},
+			errs: []string{
+				"challenge window 0 is not between 1 and 50400",
+				"resolve window 50401 is not between 1 and 50400",
+			},
+		},
+		{
+			name: "mismatched DAChallengeAddress",
+			mutate: func(cfg *config.Chain) {
+				cfg.Addresses.DAChallengeAddress = &otherAddr
+			},
+			errs: []string{"challenge contract 0x97A2dA87d3439b172e6DD027220e01c9Cb565B80 does not match DAChallengeAddress 0x16193e14197c10109F3e81b938153A04A2a00190"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := altDAChain()
+			tt.mutate(cfg)
+
+			err := ValidateAltDA(cfg)
+			if len(tt.errs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidAltDAConfig)
+			for _, msg := range tt.errs {
+				require.ErrorContains(t, err, msg)
+			}
+		})
+	}
+}
+
+func TestValidateAltDARepo(t *testing.T) {
+	rootDir, err := paths.FindRepoRoot()
+	require.NoError(t, err)
+
+	cfgs, err := CollectChainConfigs(paths.SuperchainConfigsDir(rootDir))
+	require.NoError(t, err)
+
+	for _, cfg := range cfgs {
+		require.NoError(t, ValidateAltDA(cfg.Config), "chain %s/%s", cfg.Superchain, cfg.ShortName)
+	}
+}
@@ -0,0 +1,110 @@
+package manage
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// quotedAddressRegexp matches a TOML string whose whole value is an address.
+var quotedAddressRegexp = regexp.MustCompile(`(["'])(0x[0-9a-fA-F]{40})(["'])`)
+
+// ChecksumFix is an address in a TOML file that is not EIP-55 checksummed,
+// along with its checksummed form.
+type ChecksumFix struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Key     string `json:"key"`
+	Address string `json:"address"`
+	Fixed   string `json:"fixed"`
+}
+
+func (f ChecksumFix) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s -> %s", f.File, f.Line, f.Key, f.Address, f.Fixed)
+}
+
+// FixChecksums rewrites every address in the TOML document data to its EIP-55
+// checksummed form, leaving everything else, including comments and
+// formatting, untouched. File is only used to label the returned fixes.
+func FixChecksums(file string, data []byte) ([]byte, []ChecksumFix) {
+	var fixes []ChecksumFix
+	lineKeys := tomlLineKeys(data)
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	for i, line := range lines {
+		// Only rewrite the part of the line before any comment.
+		code := stripTOMLComment(string(line))
+		fixed := quotedAddressRegexp.ReplaceAllStringFunc(code, func(match string) string {
+			parts := quotedAddressRegexp.FindStringSubmatch(match)
+			if parts[1] != parts[3] {
+				return match
+			}
+			checksummed := common.HexToAddress(parts[2]).Hex()
+			if checksummed == parts[2] {
+				return match
+			}
+
+			var key string
+			if i < len(lineKeys) {
+				key = strings.Join(lineKeys[i], ".")
+			}
+			fixes = append(fixes, ChecksumFix{
+				File:    file,
+				Line:    i + 1,
+				Key:     key,
+				Address: parts[2],
+				Fixed:   checksummed,
+			})
+			return parts[1] + checksummed + parts[3]
+		})
+		lines[i] = append([]byte(fixed), line[len(code):]...)
+	}
+	return bytes.Join(lines, nil), fixes
+}
+
+// FixChecksumsInFiles checks every TOML file in .staging and superchain/configs
+// for addresses that are not checksummed. If write is true, the files are
+// rewritten with the fixes applied; otherwise they are left untouched.
+func FixChecksumsInFiles(wd string, write bool) ([]ChecksumFix, error) {
+	var fixes []ChecksumFix
+	for _, root := range []string{paths.StagingDir(wd), paths.SuperchainConfigsDir(wd)} {
+		exists, err := fs.DirExists(root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if %s exists: %w", root, err)
+		}
+		if !exists {
+			continue
+		}
+
+		files, err := paths.CollectFiles(root, paths.FileExtMatcher(".toml"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect files in %s: %w", root, err)
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", file, err)
+			}
+
+			fixed, fileFixes := FixChecksums(file, data)
+			fixes = append(fixes, fileFixes...)
+			if !write || len(fileFixes) == 0 {
+				continue
+			}
+
+			stat, err := os.Stat(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to stat %s: %w", file, err)
+			}
+			if err := fs.AtomicWrite(file, stat.Mode().Perm(), fixed); err != nil {
+				return nil, fmt.Errorf("failed to write %s: %w", file, err)
+			}
+		}
+	}
+	return fixes, nil
+}
@@ -0,0 +1,93 @@
+package manage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixChecksums(t *testing.T) {
+	in := `name = "Test"
+batch_inbox_addr = "0x00baa763833a726f122be49cd713dafbe7254e04" # lowercase
+
+[addresses]
+  # "0x1eb2ffc903729a0f03966b917003800b145f56e2" is left alone in comments
+  ProxyAdminOwner = "0x1Eb2fFc903729a0F03966B917003800b145F56E2"
+  SystemConfigProxy = '0x034EDD2A225F7F429A63E0F1D2084B9E0A93B538'
+
+[genesis.l2]
+  hash = "0xf2071c8d0ee5e344bb56290f6b0e47c0274795a2b2a42772a340ee9e04d1bda9"
+`
+	expected := `name = "Test"
+batch_inbox_addr = "0x00BAA763833a726F122be49cd713dafbE7254e04" # lowercase
+
+[addresses]
+  # "0x1eb2ffc903729a0f03966b917003800b145f56e2" is left alone in comments
+  ProxyAdminOwner = "0x1Eb2fFc903729a0F03966B917003800b145F56E2"
+  SystemConfigProxy = '0x034edD2A225f7f429A63E0f1D2084B9E0A93b538'
+
+[genesis.l2]
+  hash = "0xf2071c8d0ee5e344bb56290f6b0e47c0274795a2b2a42772a340ee9e04d1bda9"
+`
+
+	out, fixes := FixChecksums("test.toml", []byte(in))
+	require.Equal(t, expected, string(out))
+	require.Equal(t, []ChecksumFix{
+		{
+			File:    "test.toml",
+			Line:    2,
+			Key:     "batch_inbox_addr",
+			Address: "0x00baa763833a726f122be49cd713dafbe7254e04",
+			Fixed:   "0x00BAA763833a726F122be49cd713dafbE7254e04",
+		},
+		{
+			File:    "test.toml",
+			Line:    7,
+			Key:     "addresses.SystemConfigProxy",
+			Address: "0x034EDD2A225F7F429A63E0F1D2084B9E0A93B538",
+			Fixed:   "0x034edD2A225f7f429A63E0f1D2084B9E0A93b538",
+		},
+	}, fixes)
+	require.Equal(t, "test.toml:2: batch_inbox_addr: 0x00baa763833a726f122be49cd713dafbe7254e04 -> 0x00BAA763833a726F122be49cd713dafbE7254e04", fixes[0].String())
+
+	out, fixes = FixChecksums("test.toml", out)
+	require.Equal(t, expected, string(out))
+	require.Empty(t, fixes)
+}
+
+func TestFixChecksumsInFiles(t *testing.T) {
+	wd := t.TempDir()
+	require.NoError(t, paths.EnsureDir(paths.StagingDir(wd)))
+	stagedFile := filepath.Join(paths.StagingDir(wd), "chain.toml")
+	require.NoError(t, os.WriteFile(stagedFile, []byte("batch_inbox_addr = \"0x00baa763833a726f122be49cd713dafbe7254e04\"\n"), 0o644))
+
+	fixes, err := FixChecksumsInFiles(wd, false)
+	require.NoError(t, err)
+	require.Len(t, fixes, 1)
+	data, err := os.ReadFile(stagedFile)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "0x00baa763833a726f122be49cd713dafbe7254e04")
+
+	fixes, err = FixChecksumsInFiles(wd, true)
+	require.NoError(t, err)
+	require.Len(t, fixes, 1)
+	data, err = os.ReadFile(stagedFile)
+	require.NoError(t, err)
+	require.Equal(t, "batch_inbox_addr = \"0x00BAA763833a726F122be49cd713dafbE7254e04\"\n", string(data))
+
+	fixes, err = FixChecksumsInFiles(wd, false)
+	require.NoError(t, err)
+	require.Empty(t, fixes)
+}
+
+func TestFixChecksumsRepo(t *testing.T) {
+	rootDir, err := paths.FindRepoRoot()
+	require.NoError(t, err)
+
+	fixes, err := FixChecksumsInFiles(rootDir, false)
+	require.NoError(t, err)
+	require.Empty(t, fixes)
+}
@@ -4,9 +4,9 @@ import (
 	"bytes"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	iofs "io/fs"
 	"strconv"
 	"strings"
 	"text/template"
@@ -16,7 +16,9 @@ import (
 	"github.com/ethereum-optimism/optimism/op-fetcher/pkg/fetcher/fetch/script"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/l1block"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum-optimism/superchain-registry/validation"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -25,47 +27,55 @@ type CodegenSyncer struct {
 	lgr         log.Logger
 	ChainList   []config.ChainListEntry
 	Addresses   config.AddressesJSON
-	inputWd     string
-	outputWd    string
+	reg         *Registry
+	out         fs.WriteFS
 	onchainCfgs map[uint64]script.ChainConfig
 	diskCfgs    map[uint64]DiskChainConfig
+	// L1Blocks records the L1 block the onchain data of each chain was read at,
+	// keyed by chain ID like Addresses. Only chains read at a pinned block, or
+	// synced from snapshots, have one.
+	L1Blocks map[string]l1block.Ref
+
+	nativeCurrencies map[uint64]*config.NativeCurrency
+	l1Blocks         map[uint64]l1block.Ref
 }
 
 type CodegenSyncerOption func(*CodegenSyncer)
 
 func WithOutputDirectory(outputDir string) CodegenSyncerOption {
+	return WithOutputFS(fs.NewDirFS(outputDir))
+}
+
+// WithOutputFS sets the file system the generated files are written to. By
+// default they are written to the registry they are generated from.
+func WithOutputFS(out fs.WriteFS) CodegenSyncerOption {
 	return func(s *CodegenSyncer) {
-		s.outputWd = outputDir
+		s.out = out
 	}
 }
 
-func NewCodegenSyncer(lgr log.Logger, wd string, chainCfgs map[uint64]script.ChainConfig, opts ...CodegenSyncerOption) (*CodegenSyncer, error) {
-	// Load addresses.json data
-	var addresses config.AddressesJSON
-	addressesData, err := os.ReadFile(paths.AddressesFile(wd))
-	if err != nil {
-		return nil, fmt.Errorf("error reading addresses file: %w", err)
-	}
-	if err := json.Unmarshal(addressesData, &addresses); err != nil {
-		return nil, fmt.Errorf("error unmarshaling addresses.json: %w", err)
+// WithNativeCurrencies sets the gas paying token metadata written to the chain
+// list of chains with a custom gas paying token, keyed by chain ID.
+func WithNativeCurrencies(nativeCurrencies map[uint64]*config.NativeCurrency) CodegenSyncerOption {
+	return func(s *CodegenSyncer) {
+		s.nativeCurrencies = nativeCurrencies
 	}
+}
 
-	// Load chainList.json data
-	var chainList []config.ChainListEntry
-	chainListData, err := os.ReadFile(paths.ChainListJsonFile(wd))
-	if err != nil {
-		return nil, fmt.Errorf("error reading chainList file: %w", err)
-	}
-	if err := json.Unmarshal(chainListData, &chainList); err != nil {
-		return nil, fmt.Errorf("error unmarshaling chainList file: %w", err)
+// WithL1Blocks sets the L1 block the onchain config of each chain was read at,
+// keyed by chain ID. Chains without a block have their recorded block removed.
+func WithL1Blocks(l1Blocks map[uint64]l1block.Ref) CodegenSyncerOption {
+	return func(s *CodegenSyncer) {
+		s.l1Blocks = l1Blocks
 	}
+}
 
-	// Load disk chain configs
-	diskChainCfgsSlice, err := CollectChainConfigs(paths.SuperchainConfigsDir(wd))
+func NewCodegenSyncer(lgr log.Logger, reg *Registry, chainCfgs map[uint64]script.ChainConfig, opts ...CodegenSyncerOption) (*CodegenSyncer, error) {
+	addresses := reg.Addresses()
+	chainList := reg.ChainList()
+
+	diskChainCfgsSlice := reg.Chains()
 	lgr.Info("collected chain configs from disk", "numDiskCfgs", len(diskChainCfgsSlice))
-	if err != nil {
-		return nil, fmt.Errorf("error collecting chain configs: %w", err)
-	}
 	diskChainCfgs := make(map[uint64]DiskChainConfig)
 	for _, cfg := range diskChainCfgsSlice {
 		diskChainCfgs[cfg.Config.ChainID] = cfg
@@ -96,12 +106,27 @@ func NewCodegenSyncer(lgr log.Logger, wd string, chainCfgs map[uint64]script.Cha
 		}
 	}
 
+	var l1Blocks map[string]l1block.Ref
+	if err := paths.ReadJSONFileFS(reg.FS(), paths.L1BlocksFile("."), &l1Blocks); err != nil && !errors.Is(err, iofs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read L1 blocks: %w", err)
+	}
+	filteredL1Blocks := make(map[string]l1block.Ref)
+	for chainIDStr, block := range l1Blocks {
+		chainID, err := strconv.ParseUint(chainIDStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		if _, exists := diskChainCfgs[chainID]; exists {
+			filteredL1Blocks[chainIDStr] = block
+		}
+	}
+
 	syncer := &CodegenSyncer{
 		lgr:         lgr,
 		ChainList:   filteredChainList,
 		Addresses:   filteredAddresses,
-		inputWd:     wd,
-		outputWd:    wd,
+		L1Blocks:    filteredL1Blocks,
+		reg:         reg,
 		onchainCfgs: chainCfgs,
 		diskCfgs:    diskChainCfgs,
 	}
@@ -109,6 +134,13 @@ func NewCodegenSyncer(lgr log.Logger, wd string, chainCfgs map[uint64]script.Cha
 	for _, opt := range opts {
 		opt(syncer)
 	}
+	if syncer.out == nil {
+		out, ok := reg.FS().(fs.WriteFS)
+		if !ok {
+			return nil, fmt.Errorf("registry is read-only, an output must be set")
+		}
+		syncer.out = out
+	}
 
 	return syncer, nil
 }
@@ -128,6 +160,11 @@ func (s *CodegenSyncer) ProcessSingleChain(chainId uint64, onchainCfg script.Cha
 	chainIdStr := strconv.FormatUint(chainId, 10)
 	addressesWithRoles := config.CreateAddressesWithRolesFromFetcher(onchainCfg.Addresses, onchainCfg.Roles)
 	s.Addresses[chainIdStr] = &addressesWithRoles
+	if block, ok := s.l1Blocks[chainId]; ok {
+		s.L1Blocks[chainIdStr] = block
+	} else {
+		delete(s.L1Blocks, chainIdStr)
+	}
 
 	if err := s.UpdateChainList(chainIdStr, onchainCfg); err != nil {
 		return err
@@ -159,23 +196,31 @@ func (s *CodegenSyncer) UpdateChainList(chainID string, onchainCfg script.ChainC
 		return fmt.Errorf("disk chain config not found for chain ID %s", chainID)
 	}
 
-	dir := filepath.Dir(diskCfg.Filepath)
-	superchain := filepath.Base(dir)
-
 	found := false
 	chain := diskCfg.Config
-	chainListEntry := chain.ChainListEntry(superchain, diskCfg.ShortName)
+	chainListEntry := chain.ChainListEntry(diskCfg.Superchain, diskCfg.ShortName)
 
 	if onchainCfg.FaultProofStatus == nil {
 		chainListEntry.FaultProofs = config.FaultProofs{Status: "none"}
-	} else if onchainCfg.FaultProofStatus.RespectedGameType == 0 || onchainCfg.FaultProofStatus.RespectedGameType == 8 || onchainCfg.FaultProofStatus.RespectedGameType == 9 {
-		chainListEntry.FaultProofs = config.FaultProofs{Status: "permissionless"}
 	} else {
-		chainListEntry.FaultProofs = config.FaultProofs{Status: "permissioned"}
+		respected := validation.GameType(onchainCfg.FaultProofStatus.RespectedGameType)
+		if _, ok := respected.Info(); !ok {
+			s.lgr.Warn("unknown respected game type, treating it as permissioned", "chainID", chainID, "gameType", uint32(respected))
+		}
+		chainListEntry.FaultProofs = faultProofsFor(respected)
+	}
+
+	if chain.GasPayingToken != nil {
+		chainListEntry.NativeCurrency = s.nativeCurrencies[chainIdUint64]
 	}
 
 	for i, entry := range s.ChainList {
 		if entry.ChainID == chainIdUint64 {
+			// Keep previously fetched token metadata if none was fetched this time.
+			if chainListEntry.NativeCurrency == nil && chain.GasPayingToken != nil &&
+				entry.GasPayingToken != nil && *entry.GasPayingToken == *chain.GasPayingToken {
+				chainListEntry.NativeCurrency = entry.NativeCurrency
+			}
 			s.ChainList[i] = chainListEntry
 			s.lgr.Info("updating existing chainList entry", "chainID", chainID)
 			found = true
@@ -189,30 +234,54 @@ func (s *CodegenSyncer) UpdateChainList(chainID string, onchainCfg script.ChainC
 	return nil
 }
 
-// WriteFiles writes all updated data to disk
+// faultProofsFor classifies a chain by the game type its OptimismPortal
+// respects, using the game type registry of the validation module.
+func faultProofsFor(respected validation.GameType) config.FaultProofs {
+	status := "permissioned"
+	if respected.Permissionless() {
+		status = "permissionless"
+	}
+	return config.FaultProofs{
+		Status:            status,
+		RespectedGameType: respected.String(),
+	}
+}
+
+// WriteFiles writes all updated data to the output
 func (s *CodegenSyncer) WriteFiles() error {
 	// Write addresses.json
 	updatedAddressesData, err := json.MarshalIndent(s.Addresses, "", "  ")
 	if err != nil {
 		return fmt.Errorf("error marshaling updated addresses: %w", err)
 	}
-
-	// Ensure the addresses directory exists
-	addressesPath := paths.AddressesFile(s.outputWd)
-	if err := paths.EnsureDir(filepath.Dir(addressesPath)); err != nil {
-		return fmt.Errorf("error creating addresses.json directory: %w", err)
-	}
-	if err := os.WriteFile(addressesPath, updatedAddressesData, 0o644); err != nil {
+	if err := s.out.WriteFile(paths.AddressesFile("."), updatedAddressesData, 0o644); err != nil {
 		return fmt.Errorf("error writing updated addresses.json: %w", err)
 	}
 	s.lgr.Info("successfully updated addresses.json", "updatedChains", len(s.onchainCfgs), "totalChains", len(s.Addresses))
 
+	// Write l1-blocks.json. Until a block is pinned it would only ever be
+	// empty, so it is not created for runs at the latest block.
+	_, statErr := iofs.Stat(s.reg.FS(), paths.L1BlocksFile("."))
+	if statErr != nil && !errors.Is(statErr, iofs.ErrNotExist) {
+		return fmt.Errorf("error checking l1-blocks.json: %w", statErr)
+	}
+	if len(s.L1Blocks) > 0 || statErr == nil {
+		updatedL1BlocksData, err := json.MarshalIndent(s.L1Blocks, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling updated L1 blocks: %w", err)
+		}
+		if err := s.out.WriteFile(paths.L1BlocksFile("."), updatedL1BlocksData, 0o644); err != nil {
+			return fmt.Errorf("error writing updated l1-blocks.json: %w", err)
+		}
+		s.lgr.Info("successfully updated l1-blocks.json", "updatedChains", len(s.onchainCfgs), "totalChains", len(s.L1Blocks))
+	}
+
 	// Write chainList.json
 	updatedChainListData, err := json.MarshalIndent(s.ChainList, "", "  ")
 	if err != nil {
 		return fmt.Errorf("error marshaling updated chainList: %w", err)
 	}
-	if err := os.WriteFile(paths.ChainListJsonFile(s.outputWd), updatedChainListData, 0o644); err != nil {
+	if err := s.out.WriteFile(paths.ChainListJsonFile("."), updatedChainListData, 0o644); err != nil {
 		return fmt.Errorf("error writing updated chainList.json: %w", err)
 	}
 	s.lgr.Info("successfully updated chainList.json", "updatedChains", len(s.onchainCfgs), "totalChains", len(s.ChainList))
@@ -230,15 +299,19 @@ func (s *CodegenSyncer) WriteFiles() error {
 		return fmt.Errorf("error marshaling updated chainList to TOML: %w", err)
 	}
 
-	if err := os.WriteFile(paths.ChainListTomlFile(s.outputWd), []byte(buf.String()), 0o644); err != nil {
+	if err := s.out.WriteFile(paths.ChainListTomlFile("."), []byte(buf.String()), 0o644); err != nil {
 		return fmt.Errorf("error writing updated chainList.toml: %w", err)
 	}
 	s.lgr.Info("successfully updated chainList.toml", "updatedChains", len(s.onchainCfgs), "totalChains", len(s.ChainList))
 
 	// Write CHAINS.md
-	if err := GenChainsReadme(s.inputWd, paths.ChainMdFile(s.outputWd)); err != nil {
+	readme, err := GenChainsReadme(s.reg)
+	if err != nil {
 		return fmt.Errorf("error generating readme: %w", err)
 	}
+	if err := s.out.WriteFile(paths.ChainMdFile("."), readme, 0o644); err != nil {
+		return fmt.Errorf("error writing updated CHAINS.md: %w", err)
+	}
 	s.lgr.Info("successfully updated CHAINS.md")
 
 	return nil
@@ -271,20 +344,12 @@ type ChainsReadmeData struct {
 	ChainData   [][]*config.Chain
 }
 
-func GenChainsReadme(rootP string, outP string) error {
-	superchains, err := paths.Superchains(rootP)
-	if err != nil {
-		return fmt.Errorf("error getting superchains: %w", err)
-	}
-
+// GenChainsReadme renders CHAINS.md for the chains in reg.
+func GenChainsReadme(reg *Registry) ([]byte, error) {
 	var data ChainsReadmeData
 
-	for _, superchain := range superchains {
-		cfgs, err := CollectChainConfigs(paths.SuperchainDir(rootP, superchain))
-		if err != nil {
-			return fmt.Errorf("error collecting chain configs: %w", err)
-		}
-
+	for _, superchain := range reg.Superchains() {
+		cfgs := reg.ChainsBySuperchain(superchain)
 		chainData := make([]*config.Chain, len(cfgs))
 		for i, cfg := range cfgs {
 			chainData[i] = cfg.Config
@@ -296,12 +361,8 @@ func GenChainsReadme(rootP string, outP string) error {
 
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, data); err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
+		return nil, fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	if err := fs.AtomicWrite(outP, 0o755, buf.Bytes()); err != nil {
-		return fmt.Errorf("failed to write chains readme: %w", err)
-	}
-
-	return nil
+	return buf.Bytes(), nil
 }
@@ -0,0 +1,300 @@
+package manage
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	iofs "io/fs"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+)
+
+const (
+	CodegenChainAdded   = "added"
+	CodegenChainRemoved = "removed"
+	CodegenChainChanged = "changed"
+)
+
+// CodegenFieldChange is a changed value in the entry of a chain. Values are
+// compact JSON, and Old or New is empty if the value was added or removed.
+type CodegenFieldChange struct {
+	Key string `json:"key"`
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+}
+
+// CodegenChainDiff is how the entry of a chain in a generated file differs from
+// the committed one. Changes is only set for changed entries.
+type CodegenChainDiff struct {
+	ChainID    uint64               `json:"chainId"`
+	Identifier string               `json:"identifier,omitempty"`
+	Status     string               `json:"status"`
+	Changes    []CodegenFieldChange `json:"changes,omitempty"`
+}
+
+// CodegenFileDiff is a generated file that does not match the committed one.
+// Chains is only set for addresses.json and chainList.json, which have an entry
+// per chain, and is empty if only their formatting differs.
+type CodegenFileDiff struct {
+	File    string             `json:"file"`
+	Missing bool               `json:"missing,omitempty"`
+	Chains  []CodegenChainDiff `json:"chains,omitempty"`
+}
+
+// CodegenDiff is the difference between the committed generated files and
+// freshly generated ones.
+type CodegenDiff struct {
+	Files []CodegenFileDiff `json:"files"`
+}
+
+// Stale returns whether any committed file does not match the generated one.
+func (d *CodegenDiff) Stale() bool {
+	return len(d.Files) > 0
+}
+
+// DiffCodegenFiles compares every file in generated, such as the output of a
+// CodegenSyncer created WithOutputFS, to the committed file of the same name in
+// committed. l1-blocks.json is not compared, since it records where the data
+// was read rather than the data, and a check at the latest block records none.
+func DiffCodegenFiles(committed iofs.FS, generated *fs.MemFS) (*CodegenDiff, error) {
+	identifiers := make(map[uint64]string)
+	for _, fsys := range []iofs.FS{committed, generated} {
+		var chainList []config.ChainListEntry
+		err := paths.ReadJSONFileFS(fsys, paths.ChainListJsonFile("."), &chainList)
+		if err != nil && !errors.Is(err, iofs.ErrNotExist) {
+			return nil, err
+		}
+		for _, entry := range chainList {
+			identifiers[entry.ChainID] = entry.Identifier
+		}
+	}
+
+	diff := &CodegenDiff{
+		Files: []CodegenFileDiff{},
+	}
+	for _, name := range generated.Files() {
+		if name == paths.L1BlocksFile(".") {
+			continue
+		}
+		newData, err := iofs.ReadFile(generated, name)
+		if err != nil {
+			return nil, err
+		}
+		oldData, err := iofs.ReadFile(committed, name)
+		missing := errors.Is(err, iofs.ErrNotExist)
+		if err != nil && !missing {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		if !missing && bytes.Equal(oldData, newData) {
+			continue
+		}
+
+		fileDiff := CodegenFileDiff{
+			File:    name,
+			Missing: missing,
+		}
+		var decode func([]byte) (map[uint64]json.RawMessage, error)
+		switch name {
+		case paths.AddressesFile("."):
+			decode = decodeChainIDKeyedEntries
+		case paths.ChainListJsonFile("."):
+			decode = decodeChainListEntries
+		}
+		if decode != nil {
+			oldEntries, err := decode(oldData)
+			if err != nil && !missing {
+				return nil, fmt.Errorf("failed to decode %s: %w", name, err)
+			}
+			newEntries, err := decode(newData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode generated %s: %w", name, err)
+			}
+			fileDiff.Chains, err = diffChainEntries(oldEntries, newEntries, identifiers)
+			if err != nil {
+				return nil, fmt.Errorf("failed to diff %s: %w", name, err)
+			}
+		}
+		diff.Files = append(diff.Files, fileDiff)
+	}
+	return diff, nil
+}
+
+func decodeChainIDKeyedEntries(data []byte) (map[uint64]json.RawMessage, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	out := make(map[uint64]json.RawMessage, len(raw))
+	for key, entry := range raw {
+		chainID, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chain ID %q: %w", key, err)
+		}
+		out[chainID] = entry
+	}
+	return out, nil
+}
+
+func decodeChainListEntries(data []byte) (map[uint64]json.RawMessage, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	out := make(map[uint64]json.RawMessage, len(raw))
+	for _, entry := range raw {
+		var id struct {
+			ChainID uint64 `json:"chainId"`
+		}
+		if err := json.Unmarshal(entry, &id); err != nil {
+			return nil, err
+		}
+		out[id.ChainID] = entry
+	}
+	return out, nil
+}
+
+func diffChainEntries(oldEntries, newEntries map[uint64]json.RawMessage, identifiers map[uint64]string) ([]CodegenChainDiff, error) {
+	chainIDs := slices.Sorted(maps.Keys(oldEntries))
+	for chainID := range newEntries {
+		if _, ok := oldEntries[chainID]; !ok {
+			chainIDs = append(chainIDs, chainID)
+		}
+	}
+	slices.Sort(chainIDs)
+
+	var out []CodegenChainDiff
+	for _, chainID := range chainIDs {
+		oldEntry, inOld := oldEntries[chainID]
+		newEntry, inNew := newEntries[chainID]
+		chainDiff := CodegenChainDiff{
+			ChainID:    chainID,
+			Identifier: identifiers[chainID],
+		}
+		switch {
+		case !inOld:
+			chainDiff.Status = CodegenChainAdded
+		case !inNew:
+			chainDiff.Status = CodegenChainRemoved
+		default:
+			changes, err := diffJSONValues(oldEntry, newEntry)
+			if err != nil {
+				return nil, fmt.Errorf("chain %d: %w", chainID, err)
+			}
+			if len(changes) == 0 {
+				continue
+			}
+			chainDiff.Status = CodegenChainChanged
+			chainDiff.Changes = changes
+		}
+		out = append(out, chainDiff)
+	}
+	return out, nil
+}
+
+// diffJSONValues returns the changed leaf values between two JSON objects,
+// keyed by their dotted path. Arrays are compared as a whole.
+func diffJSONValues(oldData, newData json.RawMessage) ([]CodegenFieldChange, error) {
+	oldValues, err := flattenJSON(oldData)
+	if err != nil {
+		return nil, err
+	}
+	newValues, err := flattenJSON(newData)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []CodegenFieldChange
+	for key, value := range oldValues {
+		if value != newValues[key] {
+			changes = append(changes, CodegenFieldChange{Key: key, Old: value, New: newValues[key]})
+		}
+	}
+	for key, value := range newValues {
+		if _, ok := oldValues[key]; !ok {
+			changes = append(changes, CodegenFieldChange{Key: key, New: value})
+		}
+	}
+	slices.SortFunc(changes, func(a, b CodegenFieldChange) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return changes, nil
+}
+
+func flattenJSON(data json.RawMessage) (map[string]string, error) {
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	out := make(map[string]string)
+	var flatten func(prefix string, v any) error
+	flatten = func(prefix string, v any) error {
+		if obj, ok := v.(map[string]any); ok {
+			for key, value := range obj {
+				if prefix != "" {
+					key = prefix + "." + key
+				}
+				if err := flatten(key, value); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		out[prefix] = string(encoded)
+		return nil
+	}
+	return out, flatten("", decoded)
+}
+
+// WriteText writes the stale files, and the changed chains of each.
+func (d *CodegenDiff) WriteText(w io.Writer) error {
+	var buf bytes.Buffer
+	for _, file := range d.Files {
+		switch {
+		case file.Missing:
+			fmt.Fprintf(&buf, "%s is missing\n", file.File)
+		case len(file.Chains) == 0 && isPerChainFile(file.File):
+			fmt.Fprintf(&buf, "%s is stale (formatting only)\n", file.File)
+		default:
+			fmt.Fprintf(&buf, "%s is stale\n", file.File)
+		}
+
+		for _, chain := range file.Chains {
+			label := strconv.FormatUint(chain.ChainID, 10)
+			if chain.Identifier != "" {
+				label = fmt.Sprintf("%s (%s)", label, chain.Identifier)
+			}
+			switch chain.Status {
+			case CodegenChainAdded:
+				fmt.Fprintf(&buf, "  + %s\n", label)
+			case CodegenChainRemoved:
+				fmt.Fprintf(&buf, "  - %s\n", label)
+			default:
+				fmt.Fprintf(&buf, "  ~ %s\n", label)
+			}
+			for _, change := range chain.Changes {
+				fmt.Fprintf(&buf, "      %s: %s -> %s\n", change.Key, cmp.Or(change.Old, "(unset)"), cmp.Or(change.New, "(unset)"))
+			}
+		}
+	}
+	if !d.Stale() {
+		buf.WriteString("generated files are up to date\n")
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func isPerChainFile(name string) bool {
+	return name == paths.AddressesFile(".") || name == paths.ChainListJsonFile(".")
+}
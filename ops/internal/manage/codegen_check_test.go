@@ -0,0 +1,115 @@
+package manage
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffCodegenFiles(t *testing.T) {
+	committed := fs.NewMemFS()
+	require.NoError(t, committed.WriteFile(paths.ChainListJsonFile("."), []byte(`[
+  {"chainId": 10, "identifier": "mainnet/op", "rpc": ["https://a"]},
+  {"chainId": 20, "identifier": "mainnet/old"}
+]`), 0o644))
+	require.NoError(t, committed.WriteFile(paths.AddressesFile("."), []byte(`{"10":{"L1StandardBridgeProxy":"0x01"}}`), 0o644))
+	require.NoError(t, committed.WriteFile(paths.ChainMdFile("."), []byte("same"), 0o644))
+
+	generated := fs.NewMemFS()
+	require.NoError(t, generated.WriteFile(paths.ChainListJsonFile("."), []byte(`[
+  {"chainId": 10, "identifier": "mainnet/op", "rpc": ["https://b"], "parent": {"type": "L2"}},
+  {"chainId": 30, "identifier": "mainnet/new"}
+]`), 0o644))
+	require.NoError(t, generated.WriteFile(paths.AddressesFile("."), []byte(`{
+  "10": {"L1StandardBridgeProxy": "0x01"}
+}`), 0o644))
+	require.NoError(t, generated.WriteFile(paths.ChainMdFile("."), []byte("same"), 0o644))
+	require.NoError(t, generated.WriteFile(paths.ChainListTomlFile("."), []byte("new"), 0o644))
+	// l1-blocks.json is never compared.
+	require.NoError(t, generated.WriteFile(paths.L1BlocksFile("."), []byte(`{"10":{"number":1}}`), 0o644))
+
+	diff, err := DiffCodegenFiles(committed, generated)
+	require.NoError(t, err)
+	require.True(t, diff.Stale())
+	require.Equal(t, []CodegenFileDiff{
+		{File: paths.ChainListJsonFile("."), Chains: []CodegenChainDiff{
+			{ChainID: 10, Identifier: "mainnet/op", Status: CodegenChainChanged, Changes: []CodegenFieldChange{
+				{Key: "parent.type", New: `"L2"`},
+				{Key: "rpc", Old: `["https://a"]`, New: `["https://b"]`},
+			}},
+			{ChainID: 20, Identifier: "mainnet/old", Status: CodegenChainRemoved},
+			{ChainID: 30, Identifier: "mainnet/new", Status: CodegenChainAdded},
+		}},
+		{File: paths.ChainListTomlFile("."), Missing: true},
+		{File: paths.AddressesFile(".")},
+	}, diff.Files)
+
+	var buf bytes.Buffer
+	require.NoError(t, diff.WriteText(&buf))
+	require.Equal(t, `chainList.json is stale
+  ~ 10 (mainnet/op)
+      parent.type: (unset) -> "L2"
+      rpc: ["https://a"] -> ["https://b"]
+  - 20 (mainnet/old)
+  + 30 (mainnet/new)
+chainList.toml is missing
+superchain/extra/addresses/addresses.json is stale (formatting only)
+`, buf.String())
+
+	diff, err = DiffCodegenFiles(committed, fs.NewMemFS())
+	require.NoError(t, err)
+	require.False(t, diff.Stale())
+	buf.Reset()
+	require.NoError(t, diff.WriteText(&buf))
+	require.Equal(t, "generated files are up to date\n", buf.String())
+}
+
+func TestDiffCodegenFilesPruneRemoved(t *testing.T) {
+	wd := t.TempDir()
+	writeRequiredSuperchainConfigs(t, wd)
+
+	chainListJSON, err := json.Marshal([]config.ChainListEntry{{ChainID: 123, Identifier: "sepolia/removed"}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(paths.ChainListJsonFile(wd), chainListJSON, 0o644))
+	addressesJSON, err := json.Marshal(config.AddressesJSON{"123": &config.AddressesWithRoles{}})
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(paths.AddressesFile(wd)), 0o755))
+	require.NoError(t, os.WriteFile(paths.AddressesFile(wd), addressesJSON, 0o644))
+
+	lgr := log.NewLogger(log.DiscardHandler())
+	reg, err := LoadRegistry(wd)
+	require.NoError(t, err)
+
+	generated := fs.NewMemFS()
+	require.NoError(t, PruneRemovedChains(lgr, reg, WithOutputFS(generated)))
+	diff, err := DiffCodegenFiles(reg.FS(), generated)
+	require.NoError(t, err)
+	require.True(t, diff.Stale())
+	for _, file := range diff.Files {
+		if file.File == paths.AddressesFile(".") || file.File == paths.ChainListJsonFile(".") {
+			require.Equal(t, []CodegenChainDiff{
+				{ChainID: 123, Identifier: "sepolia/removed", Status: CodegenChainRemoved},
+			}, file.Chains)
+		}
+	}
+	// Checking does not write anything.
+	data, err := os.ReadFile(paths.ChainListJsonFile(wd))
+	require.NoError(t, err)
+	require.Equal(t, chainListJSON, data)
+
+	// Once the files are regenerated, they are up to date.
+	require.NoError(t, PruneRemovedChains(lgr, reg))
+	generated = fs.NewMemFS()
+	require.NoError(t, PruneRemovedChains(lgr, reg, WithOutputFS(generated)))
+	diff, err = DiffCodegenFiles(reg.FS(), generated)
+	require.NoError(t, err)
+	require.False(t, diff.Stale(), "stale files: %v", diff.Files)
+}
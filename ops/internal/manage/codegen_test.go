@@ -3,9 +3,12 @@ package manage
 import (
 	"encoding/json"
 	"fmt"
+	iofs "io/fs"
+	"maps"
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -14,6 +17,8 @@ import (
 	"github.com/ethereum-optimism/optimism/op-chain-ops/addresses"
 	"github.com/ethereum-optimism/optimism/op-fetcher/pkg/fetcher/fetch/script"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/l1block"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -21,20 +26,21 @@ import (
 )
 
 func TestGenChainsReadme(t *testing.T) {
-	readmeFile, err := os.CreateTemp("", "chains-*.md")
+	reg, err := LoadRegistry("testdata")
+	require.NoError(t, err)
+	actualBytes, err := GenChainsReadme(reg)
 	require.NoError(t, err)
-	t.Cleanup(func() {
-		require.NoError(t, os.Remove(readmeFile.Name()))
-	})
-
-	require.NoError(t, GenChainsReadme("testdata", readmeFile.Name()))
 
 	expectedBytes, err := os.ReadFile("testdata/CHAINS.md")
 	require.NoError(t, err)
+	require.Equal(t, strings.TrimSpace(string(expectedBytes)), strings.TrimSpace(string(actualBytes)))
+}
 
-	actualBytes, err := os.ReadFile(readmeFile.Name())
+// loadTestRegistry loads the registry in testdata
+func loadTestRegistry(t *testing.T) *Registry {
+	reg, err := LoadRegistry("testdata")
 	require.NoError(t, err)
-	require.Equal(t, strings.TrimSpace(string(expectedBytes)), strings.TrimSpace(string(actualBytes)))
+	return reg
 }
 
 // loadTestAddressesJSON loads the expected addresses JSON from testdata
@@ -147,26 +153,43 @@ func convertToScriptChainConfig(t *testing.T, chainAddrs *config.AddressesWithRo
 func TestCodegenSyncer_NewCodegenSyncer(t *testing.T) {
 	chainCfgs := createTestChainConfigs(t)
 
+	reg, err := LoadRegistry("./testdata")
+	require.NoError(t, err)
+
 	// Test successful initialization
 	lgr := log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stderr, log.LevelError, false))
-	syncer, err := NewCodegenSyncer(lgr, "./testdata", chainCfgs)
+	syncer, err := NewCodegenSyncer(lgr, reg, chainCfgs)
 
 	require.NoError(t, err)
 	require.NotNil(t, syncer)
 	require.NotEmpty(t, syncer.ChainList)
 	require.NotEmpty(t, syncer.Addresses)
-	require.Equal(t, "./testdata", syncer.inputWd)
-	require.Equal(t, "./testdata", syncer.outputWd)
+	require.Equal(t, reg.FS(), syncer.out)
 
 	// Test initialization with separate output directory
 	tempDir := t.TempDir()
-	syncer, err = NewCodegenSyncer(lgr, "./testdata", chainCfgs, WithOutputDirectory(tempDir))
+	syncer, err = NewCodegenSyncer(lgr, reg, chainCfgs, WithOutputDirectory(tempDir))
 	require.NoError(t, err)
-	require.Equal(t, "./testdata", syncer.inputWd)
-	require.Equal(t, tempDir, syncer.outputWd)
+	require.Equal(t, fs.NewDirFS(tempDir), syncer.out)
 
-	// Test initialization with invalid directory
-	_, err = NewCodegenSyncer(lgr, "/nonexistent", chainCfgs)
+	// Test initialization with a read-only registry
+	readOnlyReg, err := LoadRegistryFS(os.DirFS("testdata"), false)
+	require.NoError(t, err)
+	_, err = NewCodegenSyncer(lgr, readOnlyReg, chainCfgs)
+	require.ErrorContains(t, err, "read-only")
+	out := fs.NewMemFS()
+	syncer, err = NewCodegenSyncer(lgr, readOnlyReg, chainCfgs, WithOutputFS(out))
+	require.NoError(t, err)
+	require.NoError(t, syncer.SyncAll())
+	require.Equal(t, []string{
+		"CHAINS.md",
+		"chainList.json",
+		"chainList.toml",
+		"superchain/extra/addresses/addresses.json",
+	}, out.Files())
+
+	// Test loading an invalid directory
+	_, err = LoadRegistry("/nonexistent")
 	require.Error(t, err)
 }
 
@@ -179,7 +202,7 @@ func TestCodegenSyncer_UpdateChainList(t *testing.T) {
 	}
 
 	lgr := log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stderr, log.LevelError, false))
-	syncer, err := NewCodegenSyncer(lgr, "testdata", chainCfgs)
+	syncer, err := NewCodegenSyncer(lgr, loadTestRegistry(t), chainCfgs)
 	require.NoError(t, err)
 
 	err = syncer.UpdateChainList(fmt.Sprintf("%d", testChainID), script.ChainConfig{
@@ -192,7 +215,7 @@ func TestCodegenSyncer_UpdateChainList(t *testing.T) {
 	// Verify the chain list was updated in memory
 	for _, chain := range syncer.ChainList {
 		if chain.ChainID == testChainID {
-			require.Equal(t, "permissionless", chain.FaultProofs.Status)
+			require.Equal(t, config.FaultProofs{Status: "permissionless", RespectedGameType: "CANNON"}, chain.FaultProofs)
 		}
 	}
 
@@ -201,7 +224,7 @@ func TestCodegenSyncer_UpdateChainList(t *testing.T) {
 	require.Error(t, err)
 }
 
-func TestCodegenSyncer_UpdateChainListSuperGameTypes(t *testing.T) {
+func TestCodegenSyncer_UpdateChainListGameTypes(t *testing.T) {
 	chainCfgs := createTestChainConfigs(t)
 	var testChainID uint64
 	for id := range chainCfgs {
@@ -212,14 +235,16 @@ func TestCodegenSyncer_UpdateChainListSuperGameTypes(t *testing.T) {
 	for _, test := range []struct {
 		name              string
 		respectedGameType uint32
-		expectedStatus    string
+		expected          config.FaultProofs
 	}{
-		{name: "super permissioned", respectedGameType: 5, expectedStatus: "permissioned"},
-		{name: "super cannon kona", respectedGameType: 9, expectedStatus: "permissionless"},
+		{name: "super permissioned", respectedGameType: 5, expected: config.FaultProofs{Status: "permissioned", RespectedGameType: "SUPER_PERMISSIONED_CANNON"}},
+		{name: "super cannon kona", respectedGameType: 9, expected: config.FaultProofs{Status: "permissionless", RespectedGameType: "SUPER_CANNON_KONA"}},
+		{name: "asterisc", respectedGameType: 2, expected: config.FaultProofs{Status: "permissionless", RespectedGameType: "ASTERISC"}},
+		{name: "unknown", respectedGameType: 1337, expected: config.FaultProofs{Status: "permissioned", RespectedGameType: "1337"}},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			lgr := log.NewLogger(log.DiscardHandler())
-			syncer, err := NewCodegenSyncer(lgr, "testdata", chainCfgs)
+			syncer, err := NewCodegenSyncer(lgr, loadTestRegistry(t), chainCfgs)
 			require.NoError(t, err)
 
 			err = syncer.UpdateChainList(fmt.Sprintf("%d", testChainID), script.ChainConfig{
@@ -231,7 +256,7 @@ func TestCodegenSyncer_UpdateChainListSuperGameTypes(t *testing.T) {
 
 			for _, chain := range syncer.ChainList {
 				if chain.ChainID == testChainID {
-					require.Equal(t, test.expectedStatus, chain.FaultProofs.Status)
+					require.Equal(t, test.expected, chain.FaultProofs)
 					return
 				}
 			}
@@ -240,6 +265,94 @@ func TestCodegenSyncer_UpdateChainListSuperGameTypes(t *testing.T) {
 	}
 }
 
+func TestCodegenSyncer_UpdateChainListNativeCurrency(t *testing.T) {
+	chainCfgs := createTestChainConfigs(t)
+	var testChainID uint64
+	for id := range chainCfgs {
+		testChainID = id
+		break
+	}
+
+	token := config.ChecksummedAddress(common.HexToAddress("0xA2120b9e674d3fC3875f415A7DF52e382F141225"))
+	nativeCurrency := &config.NativeCurrency{Name: "Test Token", Symbol: "TT", Decimals: 18}
+
+	findEntry := func(syncer *CodegenSyncer) config.ChainListEntry {
+		for _, entry := range syncer.ChainList {
+			if entry.ChainID == testChainID {
+				return entry
+			}
+		}
+		require.Fail(t, "test chain not found")
+		return config.ChainListEntry{}
+	}
+
+	lgr := log.NewLogger(log.DiscardHandler())
+	syncer, err := NewCodegenSyncer(lgr, loadTestRegistry(t), chainCfgs, WithNativeCurrencies(map[uint64]*config.NativeCurrency{
+		testChainID: nativeCurrency,
+	}))
+	require.NoError(t, err)
+	syncer.diskCfgs[testChainID].Config.GasPayingToken = &token
+
+	chainIDStr := fmt.Sprintf("%d", testChainID)
+	require.NoError(t, syncer.UpdateChainList(chainIDStr, chainCfgs[testChainID]))
+	entry := findEntry(syncer)
+	require.Equal(t, &token, entry.GasPayingToken)
+	require.Equal(t, nativeCurrency, entry.NativeCurrency)
+
+	// Metadata from a previous run is kept if it was not fetched again.
+	syncer.nativeCurrencies = nil
+	require.NoError(t, syncer.UpdateChainList(chainIDStr, chainCfgs[testChainID]))
+	require.Equal(t, nativeCurrency, findEntry(syncer).NativeCurrency)
+
+	// Chains without a custom gas paying token have no metadata.
+	syncer.diskCfgs[testChainID].Config.GasPayingToken = nil
+	require.NoError(t, syncer.UpdateChainList(chainIDStr, chainCfgs[testChainID]))
+	require.Nil(t, findEntry(syncer).NativeCurrency)
+}
+
+func TestCodegenSyncer_L1Blocks(t *testing.T) {
+	chainCfgs := createTestChainConfigs(t)
+	chainIDs := slices.Sorted(maps.Keys(chainCfgs))
+	require.GreaterOrEqual(t, len(chainIDs), 2)
+	pinned, unpinned := chainIDs[0], chainIDs[1]
+
+	// Blocks recorded by an earlier run, including one of a removed chain.
+	overlay := fs.NewMemFS()
+	writeJSON(t, overlay, paths.L1BlocksFile("."), map[string]l1block.Ref{
+		strconv.FormatUint(pinned, 10):   {Number: 1},
+		strconv.FormatUint(unpinned, 10): {Number: 1},
+		"999":                            {Number: 1},
+	})
+	reg, err := LoadRegistryOverlay(fs.NewDirFS("testdata"), []iofs.FS{overlay}, false)
+	require.NoError(t, err)
+
+	lgr := log.NewLogger(log.DiscardHandler())
+	block := l1block.Ref{Number: 100, Hash: common.Hash{0x01}}
+	syncer, err := NewCodegenSyncer(lgr, reg, map[uint64]script.ChainConfig{
+		pinned:   chainCfgs[pinned],
+		unpinned: chainCfgs[unpinned],
+	}, WithOutputFS(fs.NewMemFS()), WithL1Blocks(map[uint64]l1block.Ref{pinned: block}))
+	require.NoError(t, err)
+	require.NotContains(t, syncer.L1Blocks, "999")
+
+	require.NoError(t, syncer.ProcessAllChains())
+	require.Equal(t, block, syncer.L1Blocks[strconv.FormatUint(pinned, 10)])
+	// A chain synced without a known block has its old block removed.
+	require.NotContains(t, syncer.L1Blocks, strconv.FormatUint(unpinned, 10))
+
+	// An existing file is kept up to date even once no chain has a block.
+	out := fs.NewMemFS()
+	syncer, err = NewCodegenSyncer(lgr, reg, map[uint64]script.ChainConfig{
+		pinned:   chainCfgs[pinned],
+		unpinned: chainCfgs[unpinned],
+	}, WithOutputFS(out))
+	require.NoError(t, err)
+	require.NoError(t, syncer.SyncAll())
+	var recorded map[string]l1block.Ref
+	require.NoError(t, paths.ReadJSONFileFS(out, paths.L1BlocksFile("."), &recorded))
+	require.Empty(t, recorded)
+}
+
 func TestCodegenSyncer_SyncAll(t *testing.T) {
 	tempDir := t.TempDir()
 	chainCfgs := createTestChainConfigs(t)
@@ -254,7 +367,7 @@ func TestCodegenSyncer_SyncAll(t *testing.T) {
 
 	lgr := log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stderr, log.LevelError, false))
 
-	syncer, err := NewCodegenSyncer(lgr, "testdata", chainCfgs, WithOutputDirectory(tempDir))
+	syncer, err := NewCodegenSyncer(lgr, loadTestRegistry(t), chainCfgs, WithOutputDirectory(tempDir))
 	require.NoError(t, err)
 
 	err = syncer.SyncAll()
@@ -345,7 +458,9 @@ func TestCodegenSyncer_SyncAllUpdatesExistingAndPrunesRemovedChain(t *testing.T)
 	}
 
 	lgr := log.NewLogger(log.DiscardHandler())
-	syncer, err := NewCodegenSyncer(lgr, inputDir, chainCfgs, WithOutputDirectory(outputDir))
+	reg, err := LoadRegistry(inputDir)
+	require.NoError(t, err)
+	syncer, err := NewCodegenSyncer(lgr, reg, chainCfgs, WithOutputDirectory(outputDir))
 	require.NoError(t, err)
 	require.NoError(t, syncer.SyncAll())
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/BurntSushi/toml"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/util"
 )
 
@@ -24,15 +25,48 @@ type DiskChainConfig struct {
 }
 
 func CollectChainConfigs(p string) ([]DiskChainConfig, error) {
+	return collectChainConfigs(p, false)
+}
+
+// CollectChainConfigsStrict is like CollectChainConfigs, but fails if any config
+// contains keys that config.Chain has no field for.
+func CollectChainConfigsStrict(p string) ([]DiskChainConfig, error) {
+	return collectChainConfigs(p, true)
+}
+
+// CollectChainConfigsFS is like CollectChainConfigs, but reads the configs under
+// root in fsys. Filepath is set to the config's path within fsys.
+func CollectChainConfigsFS(fsys fs.FS, root string) ([]DiskChainConfig, error) {
+	var files []string
+
+	err := fs.WalkDir(fsys, root, func(fp string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isChainConfigFile(fp) {
+			return nil
+		}
+
+		files = append(files, fp)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory: %w", err)
+	}
+
+	return decodeChainConfigs(files, func(file string) ([]byte, error) {
+		return fs.ReadFile(fsys, file)
+	}, false)
+}
+
+func collectChainConfigs(p string, strict bool) ([]DiskChainConfig, error) {
 	var files []string
 
 	err := filepath.Walk(p, func(fp string, info fs.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		basePath := filepath.Base(fp)
-		ext := filepath.Ext(basePath)
-		if info.IsDir() || basePath == "superchain.toml" || ext != ".toml" {
+		if info.IsDir() || !isChainConfigFile(fp) {
 			return nil
 		}
 
@@ -43,6 +77,31 @@ func CollectChainConfigs(p string) ([]DiskChainConfig, error) {
 		return nil, fmt.Errorf("failed to walk directory: %w", err)
 	}
 
+	return decodeChainConfigs(files, os.ReadFile, strict)
+}
+
+// FindChainConfig returns the config of the chain with the given chain ID in the
+// registry at wd.
+func FindChainConfig(wd string, chainID uint64) (*DiskChainConfig, error) {
+	cfgs, err := CollectChainConfigs(paths.SuperchainConfigsDir(wd))
+	if err != nil {
+		return nil, fmt.Errorf("error collecting chain configs: %w", err)
+	}
+
+	for _, cfg := range cfgs {
+		if cfg.Config.ChainID == chainID {
+			return &cfg, nil
+		}
+	}
+	return nil, fmt.Errorf("chain with chain ID %d not found", chainID)
+}
+
+func isChainConfigFile(fp string) bool {
+	basePath := filepath.Base(fp)
+	return basePath != "superchain.toml" && filepath.Ext(basePath) == ".toml"
+}
+
+func decodeChainConfigs(files []string, readFile func(string) ([]byte, error), strict bool) ([]DiskChainConfig, error) {
 	filesCh := make(chan string)
 	firstErr := new(util.OnceValue[error])
 	var wg sync.WaitGroup
@@ -52,7 +111,7 @@ func CollectChainConfigs(p string) ([]DiskChainConfig, error) {
 	worker := func() {
 		defer wg.Done()
 		for file := range filesCh {
-			data, err := os.ReadFile(file)
+			data, err := readFile(file)
 			if err != nil {
 				firstErr.Set(fmt.Errorf("failed to read file %s: %w", file, err))
 				return
@@ -60,7 +119,12 @@ func CollectChainConfigs(p string) ([]DiskChainConfig, error) {
 
 			basename := filepath.Base(file)
 			var chain config.Chain
-			if err := toml.Unmarshal(data, &chain); err != nil {
+			if strict {
+				err = paths.UnmarshalTOMLStrict(file, data, &chain)
+			} else {
+				err = toml.Unmarshal(data, &chain)
+			}
+			if err != nil {
 				firstErr.Set(fmt.Errorf("failed to unmarshal toml %s: %w", basename, err))
 				return
 			}
@@ -41,3 +41,17 @@ func TestCollectChainConfigsMissingDir(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, chains)
 }
+
+func TestCollectChainConfigsStrict(t *testing.T) {
+	rootDir, err := paths.FindRepoRoot()
+	require.NoError(t, err)
+
+	chains, err := CollectChainConfigsStrict(paths.SuperchainConfigsDir(rootDir))
+	require.NoError(t, err)
+	require.NotEmpty(t, chains)
+
+	// testchain.toml contains deployment_tx_hash, which only exists on config.StagedChain.
+	chains, err = CollectChainConfigsStrict(paths.SuperchainDir("testdata", "sepolia"))
+	require.ErrorContains(t, err, "unknown keys in "+paths.ChainConfig("testdata", "sepolia", "testchain")+": deployment_tx_hash")
+	require.Nil(t, chains)
+}
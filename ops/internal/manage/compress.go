@@ -3,7 +3,10 @@ package manage
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	iofs "io/fs"
 	"os"
 	"path"
 
@@ -15,20 +18,32 @@ import (
 )
 
 func WriteSuperchainGenesis(rootP string, superchain config.Superchain, shortName string, gen *core.Genesis) error {
-	genPath := paths.GenesisFile(rootP, superchain, shortName)
-	exists, err := fs.FileExists(genPath)
-	if err != nil {
-		return fmt.Errorf("failed to check if genesis exists: %w", err)
-	}
-	if exists {
+	return WriteSuperchainGenesisFS(fs.NewDirFS(rootP), superchain, shortName, gen)
+}
+
+// WriteSuperchainGenesisFS is like WriteSuperchainGenesis, but writes to the
+// registry in fsys.
+func WriteSuperchainGenesisFS(fsys fs.WriteFS, superchain config.Superchain, shortName string, gen *core.Genesis) error {
+	genPath := paths.GenesisFile(".", superchain, shortName)
+	if _, err := iofs.Stat(fsys, genPath); err == nil {
 		return fmt.Errorf("genesis already exists: %s", genPath)
+	} else if !errors.Is(err, iofs.ErrNotExist) {
+		return fmt.Errorf("failed to check if genesis exists: %w", err)
 	}
 
-	if err := os.MkdirAll(path.Dir(genPath), 0o755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+	dict, err := iofs.ReadFile(fsys, path.Join(paths.ExtraDir("."), "dictionary"))
+	if err != nil {
+		return fmt.Errorf("failed to read dictionary: %w", err)
+	}
+	data, err := CompressGenesis(gen, dict)
+	if err != nil {
+		return err
 	}
 
-	return WriteGenesis(rootP, genPath, gen)
+	if err := fsys.WriteFile(genPath, data, 0o755); err != nil {
+		return fmt.Errorf("failed to write genesis: %w", err)
+	}
+	return nil
 }
 
 func WriteGenesis(rootP string, genPath string, gen *core.Genesis) error {
@@ -38,23 +53,32 @@ func WriteGenesis(rootP string, genPath string, gen *core.Genesis) error {
 		return fmt.Errorf("failed to read dictionary: %w", err)
 	}
 
+	data, err := CompressGenesis(gen, dict)
+	if err != nil {
+		return err
+	}
+
+	if err := fs.AtomicWrite(genPath, 0o755, data); err != nil {
+		return fmt.Errorf("failed to write genesis: %w", err)
+	}
+
+	return nil
+}
+
+// CompressGenesis encodes gen and compresses it with the registry's dictionary.
+func CompressGenesis(gen *core.Genesis, dict []byte) ([]byte, error) {
 	buf := new(bytes.Buffer)
 	zr, err := zstd.NewWriter(buf, zstd.WithEncoderDict(dict))
 	if err != nil {
-		return fmt.Errorf("failed to create zstd writer: %w", err)
+		return nil, fmt.Errorf("failed to create zstd writer: %w", err)
 	}
 	if err := json.NewEncoder(zr).Encode(gen); err != nil {
-		return fmt.Errorf("failed to encode genesis: %w", err)
+		return nil, fmt.Errorf("failed to encode genesis: %w", err)
 	}
 	if err := zr.Close(); err != nil {
-		return fmt.Errorf("failed to close zstd writer: %w", err)
-	}
-
-	if err := fs.AtomicWrite(genPath, 0o755, buf.Bytes()); err != nil {
-		return fmt.Errorf("failed to write genesis: %w", err)
+		return nil, fmt.Errorf("failed to close zstd writer: %w", err)
 	}
-
-	return nil
+	return buf.Bytes(), nil
 }
 
 func ReadSuperchainGenesis(rootP string, superchain config.Superchain, shortName string) (*core.Genesis, error) {
@@ -70,6 +94,27 @@ func ReadSuperchainGenesis(rootP string, superchain config.Superchain, shortName
 	return ReadGenesis(rootP, genPath)
 }
 
+// ReadSuperchainGenesisFS is like ReadSuperchainGenesis, but reads the registry
+// in fsys.
+func ReadSuperchainGenesisFS(fsys iofs.FS, superchain config.Superchain, shortName string) (*core.Genesis, error) {
+	dict, err := iofs.ReadFile(fsys, path.Join(paths.ExtraDir("."), "dictionary"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dictionary: %w", err)
+	}
+
+	genPath := paths.GenesisFile(".", superchain, shortName)
+	genF, err := fsys.Open(genPath)
+	if errors.Is(err, iofs.ErrNotExist) {
+		return nil, fmt.Errorf("genesis does not exist: %s", genPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open genesis: %w", err)
+	}
+	defer genF.Close()
+
+	return DecompressGenesis(genF, dict)
+}
+
 func ReadGenesis(rootP string, genPath string) (*core.Genesis, error) {
 	dictPath := path.Join(paths.ExtraDir(rootP), "dictionary")
 	dict, err := os.ReadFile(dictPath)
@@ -83,7 +128,13 @@ func ReadGenesis(rootP string, genPath string) (*core.Genesis, error) {
 	}
 	defer genF.Close()
 
-	zr, err := zstd.NewReader(genF, zstd.WithDecoderDicts(dict))
+	return DecompressGenesis(genF, dict)
+}
+
+// DecompressGenesis decodes a zstd-compressed genesis that was compressed with
+// the registry's dictionary.
+func DecompressGenesis(r io.Reader, dict []byte) (*core.Genesis, error) {
+	zr, err := zstd.NewReader(r, zstd.WithDecoderDicts(dict))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create zstd reader: %w", err)
 	}
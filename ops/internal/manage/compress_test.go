@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"math/big"
 	"os"
+	"path"
 	"testing"
 
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -30,6 +32,32 @@ func TestGenesisCompression(t *testing.T) {
 	require.NoError(t, err)
 
 	require.JSONEq(t, marshalGenesis(t, testGen), marshalGenesis(t, readGen))
+
+	readGen, err = ReadSuperchainGenesisFS(os.DirFS(wd), superchain, shortName)
+	require.NoError(t, err)
+	require.JSONEq(t, marshalGenesis(t, testGen), marshalGenesis(t, readGen))
+
+	_, err = ReadSuperchainGenesisFS(os.DirFS(wd), superchain, "missing")
+	require.ErrorContains(t, err, "genesis does not exist")
+}
+
+func TestGenesisCompressionFS(t *testing.T) {
+	superchain := config.MainnetSuperchain
+	shortName := "test"
+	testGen := makeTestGenesis()
+
+	dict, err := os.ReadFile(path.Join(paths.ExtraDir("testdata"), "dictionary"))
+	require.NoError(t, err)
+	mem := fs.NewMemFS()
+	require.NoError(t, mem.WriteFile(path.Join(paths.ExtraDir("."), "dictionary"), dict, 0o644))
+
+	require.NoError(t, WriteSuperchainGenesisFS(mem, superchain, shortName, testGen))
+	require.ErrorContains(t, WriteSuperchainGenesisFS(mem, superchain, shortName, testGen), "genesis already exists")
+	require.NoFileExists(t, paths.GenesisFile("testdata", superchain, shortName))
+
+	readGen, err := ReadSuperchainGenesisFS(mem, superchain, shortName)
+	require.NoError(t, err)
+	require.JSONEq(t, marshalGenesis(t, testGen), marshalGenesis(t, readGen))
 }
 
 func marshalGenesis(t *testing.T, gen *core.Genesis) string {
@@ -1,7 +1,9 @@
 package manage
 
 import (
+	"errors"
 	"fmt"
+	iofs "io/fs"
 	"os"
 	"path/filepath"
 
@@ -12,13 +14,15 @@ import (
 )
 
 func WriteChainConfig(rootP string, in *config.StagedChain) error {
-	fname := paths.ChainConfig(rootP, in.Superchain, in.ShortName)
-	exists, err := fs.FileExists(fname)
-	if err != nil {
-		return fmt.Errorf("failed to check if file exists: %w", err)
-	}
-	if exists {
-		return fmt.Errorf("file already exists: %s", fname)
+	return WriteChainConfigFS(fs.NewDirFS(rootP), in)
+}
+
+// WriteChainConfigFS is like WriteChainConfig, but writes to the registry in
+// fsys.
+func WriteChainConfigFS(fsys fs.WriteFS, in *config.StagedChain) error {
+	fname := paths.ChainConfig(".", in.Superchain, in.ShortName)
+	if err := requireNotExists(fsys, fname); err != nil {
+		return err
 	}
 
 	data, err := toml.Marshal(in.Chain)
@@ -26,7 +30,7 @@ func WriteChainConfig(rootP string, in *config.StagedChain) error {
 		return fmt.Errorf("failed to marshal toml: %w", err)
 	}
 
-	if err := fs.AtomicWrite(fname, 0o755, data); err != nil {
+	if err := fsys.WriteFile(fname, data, 0o755); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 	return nil
@@ -56,18 +60,14 @@ func ReadChainConfig(rootP string, superchain config.Superchain, shortName strin
 }
 
 func WriteSuperchainDefinition(fname string, in *config.SuperchainDefinition) error {
-	exists, err := fs.FileExists(fname)
-	if err != nil {
-		return fmt.Errorf("failed to check if file exists: %w", err)
-	}
-	if exists {
-		return fmt.Errorf("file already exists: %s", fname)
-	}
+	return WriteSuperchainDefinitionFS(fs.NewDirFS(filepath.Dir(fname)), filepath.Base(fname), in)
+}
 
-	// Ensure the directory exists
-	dir := filepath.Dir(fname)
-	if err := os.MkdirAll(dir, 0o755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+// WriteSuperchainDefinitionFS is like WriteSuperchainDefinition, but writes to
+// the file name in fsys.
+func WriteSuperchainDefinitionFS(fsys fs.WriteFS, name string, in *config.SuperchainDefinition) error {
+	if err := requireNotExists(fsys, name); err != nil {
+		return err
 	}
 
 	data, err := toml.Marshal(in)
@@ -75,8 +75,19 @@ func WriteSuperchainDefinition(fname string, in *config.SuperchainDefinition) er
 		return fmt.Errorf("failed to marshal toml: %w", err)
 	}
 
-	if err := fs.AtomicWrite(fname, 0o755, data); err != nil {
+	if err := fsys.WriteFile(name, data, 0o755); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 	return nil
 }
+
+func requireNotExists(fsys iofs.FS, name string) error {
+	_, err := iofs.Stat(fsys, name)
+	if err == nil {
+		return fmt.Errorf("file already exists: %s", name)
+	}
+	if !errors.Is(err, iofs.ErrNotExist) {
+		return fmt.Errorf("failed to check if file exists: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,303 @@
+package manage
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+)
+
+var (
+	errAsymmetricDependency = errors.New("asymmetric dependency")
+	errDanglingDependency   = errors.New("dangling dependency")
+)
+
+// DependencyNode is a chain in a DependencyGraph. Dependencies is nil for chains
+// without an interop config.
+type DependencyNode struct {
+	ChainID      uint64            `json:"chainId"`
+	Superchain   config.Superchain `json:"superchain"`
+	ShortName    string            `json:"shortName"`
+	Dependencies []uint64          `json:"dependencies"`
+}
+
+// DependencyEdge is a dependency of chain From on chain To.
+type DependencyEdge struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+// DependencyCluster is a set of chains connected by interop dependencies, in
+// either direction. A cluster is complete when every chain in it lists exactly
+// the cluster as its dependency set, which is what a valid depset looks like.
+type DependencyCluster struct {
+	ChainIDs []uint64 `json:"chainIds"`
+	Complete bool     `json:"complete"`
+}
+
+// DependencyGraph is the interop dependency graph of a set of chains, keyed by
+// chain ID.
+type DependencyGraph struct {
+	nodes map[uint64]DependencyNode
+}
+
+// NewDependencyGraph builds the dependency graph of cfgs. Every chain becomes a
+// node, whether or not it has an interop config.
+func NewDependencyGraph(cfgs []DiskChainConfig) (*DependencyGraph, error) {
+	g := &DependencyGraph{
+		nodes: make(map[uint64]DependencyNode, len(cfgs)),
+	}
+	for _, cfg := range cfgs {
+		chainID := cfg.Config.ChainID
+		if _, ok := g.nodes[chainID]; ok {
+			return nil, fmt.Errorf("duplicate chain ID %d", chainID)
+		}
+
+		node := DependencyNode{
+			ChainID:    chainID,
+			Superchain: cfg.Superchain,
+			ShortName:  cfg.ShortName,
+		}
+		if cfg.Config.Interop != nil {
+			deps, err := cfg.Config.Interop.DependencyChainIDs()
+			if err != nil {
+				return nil, fmt.Errorf("chain %d: %w", chainID, err)
+			}
+			node.Dependencies = deps
+		}
+		g.nodes[chainID] = node
+	}
+	return g, nil
+}
+
+// Node returns the node of the chain with the given chain ID.
+func (g *DependencyGraph) Node(chainID uint64) (DependencyNode, bool) {
+	node, ok := g.nodes[chainID]
+	return node, ok
+}
+
+// Nodes returns every node in the graph, sorted by chain ID.
+func (g *DependencyGraph) Nodes() []DependencyNode {
+	nodes := make([]DependencyNode, 0, len(g.nodes))
+	for _, node := range g.nodes {
+		nodes = append(nodes, node)
+	}
+	slices.SortFunc(nodes, func(a, b DependencyNode) int {
+		return cmp.Compare(a.ChainID, b.ChainID)
+	})
+	return nodes
+}
+
+// Edges returns every dependency in the graph, including a chain's dependency
+// on itself, sorted by From then To.
+func (g *DependencyGraph) Edges() []DependencyEdge {
+	var edges []DependencyEdge
+	for _, node := range g.Nodes() {
+		for _, dep := range node.Dependencies {
+			edges = append(edges, DependencyEdge{From: node.ChainID, To: dep})
+		}
+	}
+	return edges
+}
+
+// AsymmetricEdges returns the dependencies on chains in the graph that do not
+// depend back on the dependent chain.
+func (g *DependencyGraph) AsymmetricEdges() []DependencyEdge {
+	var edges []DependencyEdge
+	for _, edge := range g.Edges() {
+		if to, ok := g.nodes[edge.To]; ok && !slices.Contains(to.Dependencies, edge.From) {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// DanglingEdges returns the dependencies on chains that are not in the graph.
+func (g *DependencyGraph) DanglingEdges() []DependencyEdge {
+	var edges []DependencyEdge
+	for _, edge := range g.Edges() {
+		if _, ok := g.nodes[edge.To]; !ok {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// Validate returns an error for every asymmetric or dangling dependency.
+func (g *DependencyGraph) Validate() error {
+	var errs []error
+	for _, edge := range g.AsymmetricEdges() {
+		errs = append(errs, fmt.Errorf("%w: chain %d depends on chain %d, but not the other way around", errAsymmetricDependency, edge.From, edge.To))
+	}
+	for _, edge := range g.DanglingEdges() {
+		errs = append(errs, fmt.Errorf("%w: chain %d depends on chain %d, which is not in the registry", errDanglingDependency, edge.From, edge.To))
+	}
+	return errors.Join(errs...)
+}
+
+// Clusters returns the connected components of the chains in the graph that
+// have an interop config or are depended on, sorted by their lowest chain ID.
+// Dangling dependencies are not part of any cluster.
+func (g *DependencyGraph) Clusters() []DependencyCluster {
+	adjacent := make(map[uint64][]uint64)
+	for _, edge := range g.Edges() {
+		if _, ok := g.nodes[edge.To]; !ok {
+			continue
+		}
+		adjacent[edge.From] = append(adjacent[edge.From], edge.To)
+		adjacent[edge.To] = append(adjacent[edge.To], edge.From)
+	}
+
+	var clusters []DependencyCluster
+	visited := make(map[uint64]bool)
+	for _, node := range g.Nodes() {
+		if visited[node.ChainID] || (node.Dependencies == nil && len(adjacent[node.ChainID]) == 0) {
+			continue
+		}
+
+		var chainIDs []uint64
+		queue := []uint64{node.ChainID}
+		visited[node.ChainID] = true
+		for len(queue) > 0 {
+			chainID := queue[0]
+			queue = queue[1:]
+			chainIDs = append(chainIDs, chainID)
+			for _, next := range adjacent[chainID] {
+				if !visited[next] {
+					visited[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		slices.Sort(chainIDs)
+
+		complete := true
+		for _, chainID := range chainIDs {
+			if !slices.Equal(g.nodes[chainID].Dependencies, chainIDs) {
+				complete = false
+				break
+			}
+		}
+		clusters = append(clusters, DependencyCluster{ChainIDs: chainIDs, Complete: complete})
+	}
+	return clusters
+}
+
+// WriteDOT writes the clusters of the graph to w as a Graphviz digraph. Mutual
+// dependencies are drawn as a single two-way edge, asymmetric ones in red, and
+// dangling ones dashed to a placeholder node.
+func (g *DependencyGraph) WriteDOT(w io.Writer) error {
+	var buf bytes.Buffer
+	buf.WriteString("digraph interop {\n")
+	buf.WriteString("  rankdir=LR;\n")
+	buf.WriteString("  node [shape=box];\n")
+	for i, cluster := range g.Clusters() {
+		fmt.Fprintf(&buf, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&buf, "    label=%q;\n", g.clusterLabel(cluster))
+		for _, chainID := range cluster.ChainIDs {
+			fmt.Fprintf(&buf, "    \"%d\" [label=%q];\n", chainID, g.nodeLabel(chainID))
+		}
+		buf.WriteString("  }\n")
+	}
+	for _, edge := range g.DanglingEdges() {
+		fmt.Fprintf(&buf, "  \"%d\" [label=%q, style=dashed];\n", edge.To, g.nodeLabel(edge.To))
+	}
+	g.visitDiagramEdges(func(edge DependencyEdge, kind diagramEdgeKind) {
+		switch kind {
+		case mutualEdge:
+			fmt.Fprintf(&buf, "  \"%d\" -> \"%d\" [dir=both];\n", edge.From, edge.To)
+		case asymmetricEdge:
+			fmt.Fprintf(&buf, "  \"%d\" -> \"%d\" [color=red];\n", edge.From, edge.To)
+		case danglingEdge:
+			fmt.Fprintf(&buf, "  \"%d\" -> \"%d\" [color=red, style=dashed];\n", edge.From, edge.To)
+		}
+	})
+	buf.WriteString("}\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// WriteMermaid writes the clusters of the graph to w as a Mermaid flowchart,
+// styled the same way as WriteDOT.
+func (g *DependencyGraph) WriteMermaid(w io.Writer) error {
+	var buf bytes.Buffer
+	buf.WriteString("graph LR\n")
+	for i, cluster := range g.Clusters() {
+		fmt.Fprintf(&buf, "  subgraph cluster_%d [\"%s\"]\n", i, g.clusterLabel(cluster))
+		for _, chainID := range cluster.ChainIDs {
+			fmt.Fprintf(&buf, "    chain_%d[\"%s\"]\n", chainID, g.nodeLabel(chainID))
+		}
+		buf.WriteString("  end\n")
+	}
+	for _, edge := range g.DanglingEdges() {
+		fmt.Fprintf(&buf, "  chain_%d[\"%s\"]\n", edge.To, g.nodeLabel(edge.To))
+	}
+	var edgeIdx int
+	var redEdges []int
+	g.visitDiagramEdges(func(edge DependencyEdge, kind diagramEdgeKind) {
+		switch kind {
+		case mutualEdge:
+			fmt.Fprintf(&buf, "  chain_%d <--> chain_%d\n", edge.From, edge.To)
+		case asymmetricEdge:
+			fmt.Fprintf(&buf, "  chain_%d --> chain_%d\n", edge.From, edge.To)
+			redEdges = append(redEdges, edgeIdx)
+		case danglingEdge:
+			fmt.Fprintf(&buf, "  chain_%d -.-> chain_%d\n", edge.From, edge.To)
+			redEdges = append(redEdges, edgeIdx)
+		}
+		edgeIdx++
+	})
+	for _, idx := range redEdges {
+		fmt.Fprintf(&buf, "  linkStyle %d stroke:red\n", idx)
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+type diagramEdgeKind int
+
+const (
+	mutualEdge diagramEdgeKind = iota
+	asymmetricEdge
+	danglingEdge
+)
+
+// visitDiagramEdges calls fn for every edge to draw, in order. Self-dependencies
+// are skipped and mutual dependencies are visited once, from the lower chain ID.
+func (g *DependencyGraph) visitDiagramEdges(fn func(edge DependencyEdge, kind diagramEdgeKind)) {
+	for _, edge := range g.Edges() {
+		if edge.From == edge.To {
+			continue
+		}
+		to, ok := g.nodes[edge.To]
+		switch {
+		case !ok:
+			fn(edge, danglingEdge)
+		case !slices.Contains(to.Dependencies, edge.From):
+			fn(edge, asymmetricEdge)
+		case edge.From < edge.To:
+			fn(edge, mutualEdge)
+		}
+	}
+}
+
+func (g *DependencyGraph) nodeLabel(chainID uint64) string {
+	node, ok := g.nodes[chainID]
+	if !ok {
+		return fmt.Sprintf("unknown (%d)", chainID)
+	}
+	return fmt.Sprintf("%s/%s (%d)", node.Superchain, node.ShortName, chainID)
+}
+
+func (g *DependencyGraph) clusterLabel(cluster DependencyCluster) string {
+	if cluster.Complete {
+		return fmt.Sprintf("%d chains", len(cluster.ChainIDs))
+	}
+	return fmt.Sprintf("%d chains (incomplete)", len(cluster.ChainIDs))
+}
@@ -0,0 +1,148 @@
+package manage
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencyGraph(t *testing.T) {
+	t.Run("valid depsets", func(t *testing.T) {
+		cfgs, err := CollectChainConfigs("testdata/depsets_valid")
+		require.NoError(t, err)
+		g, err := NewDependencyGraph(cfgs)
+		require.NoError(t, err)
+
+		require.Len(t, g.Nodes(), 7)
+		node, ok := g.Node(902)
+		require.True(t, ok)
+		require.Equal(t, []uint64{901, 902}, node.Dependencies)
+		node, ok = g.Node(903)
+		require.True(t, ok)
+		require.Nil(t, node.Dependencies)
+
+		require.Equal(t, []DependencyCluster{
+			{ChainIDs: []uint64{901, 902}, Complete: true},
+			{ChainIDs: []uint64{905, 906, 907}, Complete: true},
+		}, g.Clusters())
+		require.Empty(t, g.AsymmetricEdges())
+		require.Empty(t, g.DanglingEdges())
+		require.NoError(t, g.Validate())
+
+		var buf bytes.Buffer
+		require.NoError(t, g.WriteDOT(&buf))
+		require.Equal(t, `digraph interop {
+  rankdir=LR;
+  node [shape=box];
+  subgraph cluster_0 {
+    label="2 chains";
+    "901" [label="depsets_valid/chain1 (901)"];
+    "902" [label="depsets_valid/chain2 (902)"];
+  }
+  subgraph cluster_1 {
+    label="3 chains";
+    "905" [label="depsets_valid/chain5 (905)"];
+    "906" [label="depsets_valid/chain6 (906)"];
+    "907" [label="depsets_valid/chain7 (907)"];
+  }
+  "901" -> "902" [dir=both];
+  "905" -> "906" [dir=both];
+  "905" -> "907" [dir=both];
+  "906" -> "907" [dir=both];
+}
+`, buf.String())
+	})
+
+	t.Run("asymmetric depsets", func(t *testing.T) {
+		cfgs, err := CollectChainConfigs("testdata/depsets_invalid/transience")
+		require.NoError(t, err)
+		g, err := NewDependencyGraph(cfgs)
+		require.NoError(t, err)
+
+		require.Equal(t, []DependencyCluster{
+			{ChainIDs: []uint64{901, 902, 903, 904, 905}, Complete: false},
+		}, g.Clusters())
+		require.Equal(t, []DependencyEdge{
+			{From: 904, To: 901},
+			{From: 905, To: 901},
+		}, g.AsymmetricEdges())
+		require.Empty(t, g.DanglingEdges())
+
+		err = g.Validate()
+		require.ErrorIs(t, err, errAsymmetricDependency)
+		require.ErrorContains(t, err, "chain 904 depends on chain 901, but not the other way around")
+	})
+
+	t.Run("dangling dependency", func(t *testing.T) {
+		g, err := NewDependencyGraph([]DiskChainConfig{
+			interopChain("a", 1, "1", "2", "99"),
+			interopChain("b", 2, "1", "2"),
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, []DependencyCluster{
+			{ChainIDs: []uint64{1, 2}, Complete: false},
+		}, g.Clusters())
+		require.Empty(t, g.AsymmetricEdges())
+		require.Equal(t, []DependencyEdge{{From: 1, To: 99}}, g.DanglingEdges())
+		require.ErrorIs(t, g.Validate(), errDanglingDependency)
+
+		var buf bytes.Buffer
+		require.NoError(t, g.WriteMermaid(&buf))
+		require.Equal(t, `graph LR
+  subgraph cluster_0 ["2 chains (incomplete)"]
+    chain_1["mainnet/a (1)"]
+    chain_2["mainnet/b (2)"]
+  end
+  chain_99["unknown (99)"]
+  chain_1 <--> chain_2
+  chain_1 -.-> chain_99
+  linkStyle 1 stroke:red
+`, buf.String())
+	})
+
+	t.Run("invalid configs", func(t *testing.T) {
+		_, err := NewDependencyGraph([]DiskChainConfig{
+			interopChain("a", 1, "1"),
+			interopChain("b", 1, "1"),
+		})
+		require.ErrorContains(t, err, "duplicate chain ID 1")
+
+		_, err = NewDependencyGraph([]DiskChainConfig{
+			interopChain("a", 1, "1", "b"),
+		})
+		require.ErrorContains(t, err, `invalid dependency chain ID "b"`)
+	})
+
+	t.Run("actual depsets", func(t *testing.T) {
+		rootDir, err := paths.FindRepoRoot()
+		require.NoError(t, err)
+
+		cfgs, err := CollectChainConfigs(paths.SuperchainConfigsDir(rootDir))
+		require.NoError(t, err)
+		g, err := NewDependencyGraph(cfgs)
+		require.NoError(t, err)
+		require.NoError(t, g.Validate())
+		for _, cluster := range g.Clusters() {
+			require.True(t, cluster.Complete, "cluster %v", cluster.ChainIDs)
+		}
+	})
+}
+
+func interopChain(shortName string, chainID uint64, deps ...string) DiskChainConfig {
+	interop := &config.Interop{Dependencies: make(map[string]config.StaticConfigDependency)}
+	for _, dep := range deps {
+		interop.Dependencies[dep] = config.StaticConfigDependency{}
+	}
+	return DiskChainConfig{
+		Superchain: "mainnet",
+		ShortName:  shortName,
+		Config: &config.Chain{
+			ChainID: chainID,
+			Interop: interop,
+		},
+	}
+}
@@ -3,7 +3,6 @@ package manage
 import (
 	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
@@ -58,17 +57,15 @@ func (dc *DepsetChecker) Check() error {
 
 		// collect all chains in the depset, then process them
 		depsetCfgs := []DiskChainConfig{}
-		depset := cfg.Config.Interop.Dependencies
-
-		for chainId := range depset {
-			chainIdUint64, err := strconv.ParseUint(chainId, 10, 64)
-			if err != nil {
-				return fmt.Errorf("chain ID cannot be converted to a uint64: %s", chainId)
-			}
-			if _, ok := dc.diskChainCfgs[chainIdUint64]; !ok {
-				return fmt.Errorf("chain ID %d not found in diskChainCfgs", chainIdUint64)
+		depset, err := cfg.Config.Interop.DependencyChainIDs()
+		if err != nil {
+			return fmt.Errorf("chain %d: %w", cfg.Config.ChainID, err)
+		}
+		for _, chainID := range depset {
+			if _, ok := dc.diskChainCfgs[chainID]; !ok {
+				return fmt.Errorf("chain ID %d not found in diskChainCfgs", chainID)
 			}
-			depsetCfgs = append(depsetCfgs, dc.diskChainCfgs[chainIdUint64])
+			depsetCfgs = append(depsetCfgs, dc.diskChainCfgs[chainID])
 		}
 
 		if err := dc.checkOffchain(depsetCfgs); err != nil {
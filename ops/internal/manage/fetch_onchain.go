@@ -2,78 +2,156 @@ package manage
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"math/big"
+	"strings"
 	"sync"
 
 	"github.com/ethereum-optimism/optimism/op-fetcher/pkg/fetcher/fetch"
 	"github.com/ethereum-optimism/optimism/op-fetcher/pkg/fetcher/fetch/script"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
-	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/l1block"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/report"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 	"golang.org/x/sync/errgroup"
 )
 
-// FetchChains fetches chain configurations for specified chain IDs or all chains if none specified
-func FetchChains(egCtx context.Context, lgr log.Logger, wd string, l1RpcUrls []string, chainIds []uint64, superchains []config.Superchain) (map[uint64]script.ChainConfig, error) {
-	chainsBySuperchain, err := collectChainsBySuperchain(wd, chainIds, superchains)
+// FetchChains fetches onchain snapshots for specified chain IDs or all chains if none specified.
+// The L1 block of each superchain is fixed before its chains are fetched, and
+// every read of them is made at it: the block in blocks if one is given, or the
+// latest block otherwise. The block is recorded in their snapshots.
+func FetchChains(ctx context.Context, lgr log.Logger, reg *Registry, l1RpcUrls []string, chainIds []uint64, superchains []config.Superchain, blocks map[config.Superchain]l1block.Spec) (map[uint64]*OnchainSnapshot, error) {
+	chainsBySuperchain, err := collectChainsBySuperchain(reg, chainIds, superchains)
 	if err != nil {
 		return nil, err
 	}
-
-	allChainConfigs := make(map[uint64]script.ChainConfig)
-	var mu sync.Mutex
-	eg, egCtx := errgroup.WithContext(egCtx)
-
-	superchainIds, err := paths.SuperchainIds(wd)
-	if err != nil {
-		return nil, fmt.Errorf("error getting superchain chainIds: %w", err)
+	for superchain := range blocks {
+		if _, ok := reg.SuperchainDefinition(superchain); !ok {
+			return nil, fmt.Errorf("L1 block given for unknown superchain %s", superchain)
+		}
 	}
 
-	for superchain, chains := range chainsBySuperchain {
-		lgr.Info("fetching superchain", "superchain", superchain, "numChains", len(chains))
-		superchainId, ok := superchainIds[superchain]
+	// Every superchain's L1 block is resolved before any fetch starts, so an
+	// invalid URL or block fails the run without leaving fetches behind.
+	l1Reads := make(map[config.Superchain]superchainL1Read)
+	for superchain := range chainsBySuperchain {
+		superchainDef, ok := reg.SuperchainDefinition(superchain)
 		if !ok {
 			return nil, fmt.Errorf("missing superchain chainId for superchain %s", superchain)
 		}
 
-		l1RpcUrl, err := config.FindValidL1URL(egCtx, lgr, l1RpcUrls, superchainId)
+		l1RpcUrl, err := config.FindValidL1URL(ctx, lgr, l1RpcUrls, superchainDef.L1.ChainID)
 		if err != nil {
 			return nil, fmt.Errorf("missing L1 RPC URL for superchain %s", superchain)
 		}
+		l1Block, err := resolveL1Block(ctx, l1RpcUrl, blocks[superchain])
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve L1 block for superchain %s: %w", superchain, err)
+		}
+		lgr.Info("fetching at L1 block", "superchain", superchain, "number", l1Block.Number, "hash", l1Block.Hash)
+		l1Reads[superchain] = superchainL1Read{rpcUrl: l1RpcUrl, block: l1Block}
+	}
 
-		for _, cfg := range chains {
-			// Capture variables for goroutine
-			currentConfig := cfg
-			currentRpcUrl := l1RpcUrl
+	allSnapshots := make(map[uint64]*OnchainSnapshot)
+	var mu sync.Mutex
+	eg, egCtx := errgroup.WithContext(ctx)
+	for superchain, chains := range chainsBySuperchain {
+		lgr.Info("fetching superchain", "superchain", superchain, "numChains", len(chains))
+		eg.Go(func() error {
+			snapshots, err := fetchSuperchain(egCtx, lgr, l1Reads[superchain], chains)
+			if err != nil {
+				return fmt.Errorf("failed to fetch superchain %s: %w", superchain, err)
+			}
+			mu.Lock()
+			maps.Copy(allSnapshots, snapshots)
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
 
-			eg.Go(func() error {
-				result, err := fetchChainInfo(egCtx, lgr, currentRpcUrl, currentConfig)
-				if err != nil {
-					return fmt.Errorf("failed to fetch chain info for chainId %d: %w", currentConfig.Config.ChainID, err)
-				}
+	lgr.Info("completed fetching", "totalChains", len(allSnapshots))
+	return allSnapshots, nil
+}
 
-				mu.Lock()
-				allChainConfigs[currentConfig.Config.ChainID] = result
-				mu.Unlock()
+// superchainL1Read is the L1 RPC and block the chains of a superchain are read
+// from.
+type superchainL1Read struct {
+	rpcUrl string
+	block  l1block.Ref
+}
+
+// fetchSuperchain fetches the snapshots of chains, which all belong to the
+// superchain l1 is the L1 of. It returns once every fetch has finished.
+func fetchSuperchain(ctx context.Context, lgr log.Logger, l1 superchainL1Read, chains []DiskChainConfig) (map[uint64]*OnchainSnapshot, error) {
+	// op-fetcher only takes an RPC URL, so its reads are pinned by a proxy.
+	proxy, err := l1block.NewProxy(l1.rpcUrl, l1.block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pin L1 RPC: %w", err)
+	}
+	defer proxy.Close()
+
+	snapshots := make(map[uint64]*OnchainSnapshot)
+	nativeCurrencies := make(map[uint64]*config.NativeCurrency)
+	var mu sync.Mutex
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, cfg := range chains {
+		eg.Go(func() error {
+			result, err := fetchChainInfo(egCtx, lgr, proxy.URL(), cfg)
+			if err != nil {
+				return fmt.Errorf("failed to fetch chain info for chainId %d: %w", cfg.Config.ChainID, err)
+			}
+
+			mu.Lock()
+			snapshots[cfg.Config.ChainID] = &OnchainSnapshot{
+				ChainID: cfg.Config.ChainID,
+				L1Block: l1.block,
+				Config:  result,
+			}
+			mu.Unlock()
+
+			lgr.Info("fetched chain config", "chainId", cfg.Config.ChainID)
+			return nil
+		})
+	}
 
-				lgr.Info("fetched chain config", "chainId", currentConfig.Config.ChainID)
-				return nil
-			})
+	var tokenChains []DiskChainConfig
+	for _, cfg := range chains {
+		if cfg.Config.GasPayingToken != nil {
+			tokenChains = append(tokenChains, cfg)
 		}
 	}
+	if len(tokenChains) > 0 {
+		eg.Go(func() error {
+			fetched := make(map[uint64]*config.NativeCurrency)
+			if err := fetchNativeCurrencies(egCtx, lgr, l1.rpcUrl, l1.block.BigNumber(), tokenChains, fetched); err != nil {
+				return err
+			}
+			mu.Lock()
+			maps.Copy(nativeCurrencies, fetched)
+			mu.Unlock()
+			return nil
+		})
+	}
 
 	if err := eg.Wait(); err != nil {
 		return nil, err
 	}
-
-	lgr.Info("completed fetching", "totalChains", len(allChainConfigs))
-	return allChainConfigs, nil
+	for chainID, nativeCurrency := range nativeCurrencies {
+		snapshots[chainID].NativeCurrency = nativeCurrency
+	}
+	return snapshots, nil
 }
 
 // collectChainsBySuperchain assembles a map of chains grouped by their superchain
 // based on provided chainIds or superchains or all chains if neither are provided
-func collectChainsBySuperchain(wd string, chainIds []uint64, superchainsInput []config.Superchain) (map[config.Superchain][]DiskChainConfig, error) {
+func collectChainsBySuperchain(reg *Registry, chainIds []uint64, superchainsInput []config.Superchain) (map[config.Superchain][]DiskChainConfig, error) {
 	result := make(map[config.Superchain][]DiskChainConfig)
 	if len(chainIds) > 0 && len(superchainsInput) > 0 {
 		return nil, fmt.Errorf("cannot provide both chainIds and superchains inputs")
@@ -81,11 +159,7 @@ func collectChainsBySuperchain(wd string, chainIds []uint64, superchainsInput []
 
 	superchains := superchainsInput
 	if len(superchains) == 0 {
-		var err error
-		superchains, err = paths.Superchains(wd)
-		if err != nil {
-			return nil, fmt.Errorf("error getting superchains: %w", err)
-		}
+		superchains = reg.Superchains()
 	}
 
 	// Create a map for quick chain ID lookup if we're filtering
@@ -99,10 +173,10 @@ func collectChainsBySuperchain(wd string, chainIds []uint64, superchainsInput []
 	// Process all superchains
 	for _, superchain := range superchains {
 		// Collect all chain configs from this superchain
-		configs, err := CollectChainConfigs(paths.SuperchainDir(wd, superchain))
-		if err != nil {
-			return nil, fmt.Errorf("error collecting chain configs for superchain %s: %w", superchain, err)
+		if _, ok := reg.SuperchainDefinition(superchain); !ok {
+			return nil, fmt.Errorf("error collecting chain configs for superchain %s: superchain not found", superchain)
 		}
+		configs := reg.ChainsBySuperchain(superchain)
 
 		// Filter configs if chainIds is specified
 		if len(chainIds) > 0 {
@@ -155,3 +229,74 @@ func fetchChainInfo(ctx context.Context, lgr log.Logger, l1RpcUrl string, cfg Di
 
 	return script.CreateChainConfig(result), nil
 }
+
+// resolveL1Block returns the block spec selects on the L1 at l1RpcUrl.
+func resolveL1Block(ctx context.Context, l1RpcUrl string, spec l1block.Spec) (l1block.Ref, error) {
+	rpcClient, err := rpc.DialContext(ctx, l1RpcUrl)
+	if err != nil {
+		return l1block.Ref{}, fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer rpcClient.Close()
+
+	return l1block.Resolve(ctx, rpcClient, spec)
+}
+
+// fetchNativeCurrencies reads the ERC-20 metadata of the custom gas paying token
+// of every chain in cfgs at block into out. It fails if the SystemConfig of any
+// chain disagrees with its config about the token, after checking all of them.
+// Chains whose SystemConfig cannot report a token are only logged, since
+// releases that removed custom gas token support cannot report it.
+func fetchNativeCurrencies(ctx context.Context, lgr log.Logger, l1RpcUrl string, block *big.Int, cfgs []DiskChainConfig, out map[uint64]*config.NativeCurrency) error {
+	rpcClient, err := rpc.DialContext(ctx, l1RpcUrl)
+	if err != nil {
+		return fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer rpcClient.Close()
+
+	var errs []error
+	for _, cfg := range cfgs {
+		if cfg.Config.Addresses.SystemConfigProxy == nil {
+			return fmt.Errorf("chain %d has no SystemConfigProxy address", cfg.Config.ChainID)
+		}
+
+		tokenReport, err := report.ScanGasPayingToken(
+			ctx,
+			rpcClient,
+			block,
+			common.Address(*cfg.Config.Addresses.SystemConfigProxy),
+			common.Address(*cfg.Config.GasPayingToken),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to fetch gas paying token for chain %d: %w", cfg.Config.ChainID, err)
+		}
+		if tokenReport.SystemConfigErr != "" {
+			lgr.Warn("SystemConfig does not report a gas paying token", "chainId", cfg.Config.ChainID, "err", tokenReport.SystemConfigErr)
+		}
+
+		nativeCurrency, err := nativeCurrencyFor(cfg.Config.ChainID, tokenReport)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		out[cfg.Config.ChainID] = nativeCurrency
+		lgr.Info("fetched gas paying token", "chainId", cfg.Config.ChainID, "symbol", tokenReport.Symbol)
+	}
+	return errors.Join(errs...)
+}
+
+// nativeCurrencyFor returns the metadata of the gas paying token in tokenReport,
+// or an error listing the mismatches if the SystemConfig disagrees with it. A
+// SystemConfig that cannot report a token has nothing to disagree with.
+func nativeCurrencyFor(chainID uint64, tokenReport report.L1GasPayingTokenReport) (*config.NativeCurrency, error) {
+	if tokenReport.SystemConfigErr == "" {
+		if mismatches := tokenReport.Mismatches(); len(mismatches) > 0 {
+			return nil, fmt.Errorf("gas paying token of chain %d does not match its SystemConfig: %s", chainID, strings.Join(mismatches, "; "))
+		}
+	}
+
+	return &config.NativeCurrency{
+		Name:     tokenReport.Name,
+		Symbol:   tokenReport.Symbol,
+		Decimals: tokenReport.Decimals,
+	}, nil
+}
@@ -5,19 +5,23 @@ import (
 	"testing"
 
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/report"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
 func TestCollectChainsBySuperchain(t *testing.T) {
 	testdataDir, err := filepath.Abs("testdata")
 	require.NoError(t, err, "Failed to get absolute path to testdata")
+	reg, err := LoadRegistry(testdataDir)
+	require.NoError(t, err)
 
 	// Known testdata chainIds
 	opSepolia := uint64(11155420)
 	testChain := uint64(1952805748)
 
 	t.Run("all chains", func(t *testing.T) {
-		chains, err := collectChainsBySuperchain(testdataDir, []uint64{}, []config.Superchain{})
+		chains, err := collectChainsBySuperchain(reg, []uint64{}, []config.Superchain{})
 		require.NoError(t, err)
 
 		require.Equal(t, len(chains[config.SepoliaSuperchain]), 2)
@@ -26,7 +30,7 @@ func TestCollectChainsBySuperchain(t *testing.T) {
 	})
 
 	t.Run("single chain", func(t *testing.T) {
-		chains, err := collectChainsBySuperchain(testdataDir, []uint64{opSepolia}, []config.Superchain{})
+		chains, err := collectChainsBySuperchain(reg, []uint64{opSepolia}, []config.Superchain{})
 		require.NoError(t, err)
 
 		require.Equal(t, len(chains[config.SepoliaSuperchain]), 1)
@@ -35,7 +39,7 @@ func TestCollectChainsBySuperchain(t *testing.T) {
 	})
 
 	t.Run("two chains", func(t *testing.T) {
-		chains, err := collectChainsBySuperchain(testdataDir, []uint64{opSepolia, testChain}, []config.Superchain{})
+		chains, err := collectChainsBySuperchain(reg, []uint64{opSepolia, testChain}, []config.Superchain{})
 		require.NoError(t, err)
 
 		require.Equal(t, len(chains[config.SepoliaSuperchain]), 2)
@@ -44,12 +48,12 @@ func TestCollectChainsBySuperchain(t *testing.T) {
 	})
 
 	t.Run("fails for non-existent chainId", func(t *testing.T) {
-		_, err := collectChainsBySuperchain(testdataDir, []uint64{999999999}, []config.Superchain{})
+		_, err := collectChainsBySuperchain(reg, []uint64{999999999}, []config.Superchain{})
 		require.Error(t, err)
 	})
 
 	t.Run("sepolia superchain", func(t *testing.T) {
-		chains, err := collectChainsBySuperchain(testdataDir, []uint64{}, []config.Superchain{config.SepoliaSuperchain})
+		chains, err := collectChainsBySuperchain(reg, []uint64{}, []config.Superchain{config.SepoliaSuperchain})
 		require.NoError(t, err)
 
 		require.Equal(t, len(chains[config.SepoliaSuperchain]), 2)
@@ -58,12 +62,45 @@ func TestCollectChainsBySuperchain(t *testing.T) {
 	})
 
 	t.Run("fails for deleted superchain", func(t *testing.T) {
-		_, err := collectChainsBySuperchain(t.TempDir(), []uint64{}, []config.Superchain{"deleted"})
+		_, err := collectChainsBySuperchain(reg, []uint64{}, []config.Superchain{"deleted"})
 		require.Error(t, err)
 	})
 
 	t.Run("fails if both chainIds and superchains are provided", func(t *testing.T) {
-		_, err := collectChainsBySuperchain(testdataDir, []uint64{opSepolia}, []config.Superchain{config.SepoliaSuperchain})
+		_, err := collectChainsBySuperchain(reg, []uint64{opSepolia}, []config.Superchain{config.SepoliaSuperchain})
 		require.Error(t, err)
 	})
 }
+
+func TestNativeCurrencyFor(t *testing.T) {
+	token := common.HexToAddress("0xA2120b9e674d3fC3875f415A7DF52e382F141225")
+	tokenReport := report.L1GasPayingTokenReport{
+		Token:                     token,
+		Name:                      "Test Token",
+		Symbol:                    "TT",
+		Decimals:                  18,
+		SystemConfigToken:         token,
+		SystemConfigTokenName:     "Test Token",
+		SystemConfigTokenSymbol:   "TT",
+		SystemConfigTokenDecimals: 18,
+	}
+	want := &config.NativeCurrency{Name: "Test Token", Symbol: "TT", Decimals: 18}
+
+	nativeCurrency, err := nativeCurrencyFor(1, tokenReport)
+	require.NoError(t, err)
+	require.Equal(t, want, nativeCurrency)
+
+	mismatched := tokenReport
+	mismatched.SystemConfigToken = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+	mismatched.SystemConfigTokenSymbol = "ETH"
+	_, err = nativeCurrencyFor(1, mismatched)
+	require.ErrorContains(t, err, "gas paying token of chain 1 does not match its SystemConfig")
+	require.ErrorContains(t, err, `SystemConfig reports token symbol "ETH", token has "TT"`)
+
+	unsupported := tokenReport
+	unsupported.SystemConfigToken = common.Address{}
+	unsupported.SystemConfigErr = "execution reverted"
+	nativeCurrency, err = nativeCurrencyFor(1, unsupported)
+	require.NoError(t, err)
+	require.Equal(t, want, nativeCurrency)
+}
@@ -0,0 +1,252 @@
+package manage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	iofs "io/fs"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+)
+
+// HardforkSchedule is the status of every hardfork across the registry at a given timestamp.
+type HardforkSchedule struct {
+	Timestamp   uint64                       `json:"timestamp"`
+	Superchains []SuperchainHardforkSchedule `json:"superchains"`
+}
+
+type SuperchainHardforkSchedule struct {
+	Superchain config.Superchain           `json:"superchain"`
+	Name       string                      `json:"name"`
+	Hardforks  []config.HardforkActivation `json:"hardforks"`
+	Chains     []ChainHardforkSchedule     `json:"chains"`
+}
+
+type ChainHardforkSchedule struct {
+	Name      string                      `json:"name"`
+	ShortName string                      `json:"shortName"`
+	ChainID   uint64                      `json:"chainId"`
+	Hardforks []config.HardforkActivation `json:"hardforks"`
+}
+
+// BuildHardforkSchedule loads every superchain definition and chain config of
+// the registry in fsys and reports which hardforks are active, pending or unset
+// at timestamp t.
+func BuildHardforkSchedule(fsys iofs.FS, t uint64) (*HardforkSchedule, error) {
+	superchains, err := paths.SuperchainsFS(fsys)
+	if err != nil {
+		return nil, fmt.Errorf("error getting superchains: %w", err)
+	}
+
+	schedule := &HardforkSchedule{Timestamp: t}
+	for _, superchain := range superchains {
+		var def config.SuperchainDefinition
+		if err := paths.ReadTOMLFileFS(fsys, paths.SuperchainConfig(".", superchain), &def); err != nil {
+			return nil, fmt.Errorf("error reading superchain config for %s: %w", superchain, err)
+		}
+
+		cfgs, err := CollectChainConfigsFS(fsys, paths.SuperchainDir(".", superchain))
+		if err != nil {
+			return nil, fmt.Errorf("error collecting chain configs for %s: %w", superchain, err)
+		}
+
+		scSchedule := SuperchainHardforkSchedule{
+			Superchain: superchain,
+			Name:       def.Name,
+			Hardforks:  def.Hardforks.StatusAt(t),
+		}
+		for _, cfg := range cfgs {
+			scSchedule.Chains = append(scSchedule.Chains, ChainHardforkSchedule{
+				Name:      cfg.Config.Name,
+				ShortName: cfg.ShortName,
+				ChainID:   cfg.Config.ChainID,
+				Hardforks: cfg.Config.Hardforks.StatusAt(t),
+			})
+		}
+		schedule.Superchains = append(schedule.Superchains, scSchedule)
+	}
+
+	return schedule, nil
+}
+
+// ActiveHardforks returns the hardforks active at timestamp t for the chain
+// with the given chain ID in the registry in fsys.
+func ActiveHardforks(fsys iofs.FS, chainID uint64, t uint64) ([]config.Hardfork, error) {
+	cfgs, err := CollectChainConfigsFS(fsys, paths.SuperchainConfigsDir("."))
+	if err != nil {
+		return nil, fmt.Errorf("error collecting chain configs: %w", err)
+	}
+
+	for _, cfg := range cfgs {
+		if cfg.Config.ChainID == chainID {
+			return cfg.Config.Hardforks.ActiveAt(t), nil
+		}
+	}
+	return nil, fmt.Errorf("chain with chain ID %d not found", chainID)
+}
+
+func (s *HardforkSchedule) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		return fmt.Errorf("failed to encode hardfork schedule: %w", err)
+	}
+	return nil
+}
+
+// WriteMarkdown writes one table per superchain, with a row for the superchain
+// definition followed by a row per chain.
+func (s *HardforkSchedule) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Hardfork Schedule\n\nStatus at %s.\n", formatScheduleTime(s.Timestamp))
+
+	for _, sc := range s.Superchains {
+		fmt.Fprintf(&b, "\n## %s\n\n| Chain |", sc.Superchain)
+		for _, fork := range config.AllHardforks {
+			fmt.Fprintf(&b, " %s |", fork.Name)
+		}
+		b.WriteString("\n|---|")
+		b.WriteString(strings.Repeat("---|", len(config.AllHardforks)))
+		b.WriteString("\n")
+
+		writeMarkdownRow(&b, fmt.Sprintf("**%s** (superchain)", sc.Name), sc.Hardforks)
+		for _, chain := range sc.Chains {
+			writeMarkdownRow(&b, fmt.Sprintf("%s (%d)", chain.Name, chain.ChainID), chain.Hardforks)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdownRow(b *strings.Builder, label string, activations []config.HardforkActivation) {
+	fmt.Fprintf(b, "| %s |", label)
+	for _, activation := range activations {
+		switch activation.Status {
+		case config.HardforkActive:
+			fmt.Fprintf(b, " ✅ %s |", formatActivationDate(*activation.Time))
+		case config.HardforkPending:
+			fmt.Fprintf(b, " ⏳ %s |", formatActivationDate(*activation.Time))
+		default:
+			b.WriteString(" - |")
+		}
+	}
+	b.WriteString("\n")
+}
+
+// WriteICS writes an iCalendar file with an event for every superchain-wide
+// activation, plus an event for every chain whose activation time differs from
+// its superchain's. Activations at genesis (time 0) are omitted.
+func (s *HardforkSchedule) WriteICS(w io.Writer) error {
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//ethereum-optimism//superchain-registry hardfork schedule//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+
+	stamp := icsTime(s.Timestamp)
+	for _, sc := range s.Superchains {
+		for _, activation := range sc.Hardforks {
+			if activation.Time == nil || *activation.Time == 0 {
+				continue
+			}
+			writeICSEvent(&b, icsEvent{
+				uid:         fmt.Sprintf("%s-%s@superchain-registry", sc.Superchain, activation.Key),
+				stamp:       stamp,
+				start:       *activation.Time,
+				summary:     fmt.Sprintf("%s activation (%s superchain)", activation.Name, sc.Superchain),
+				description: fmt.Sprintf("Superchain-wide %s activation for %s, inherited by opted-in chains.", activation.Name, sc.Name),
+			})
+		}
+
+		for _, chain := range sc.Chains {
+			for i, activation := range chain.Hardforks {
+				if activation.Time == nil || *activation.Time == 0 {
+					continue
+				}
+				if scTime := sc.Hardforks[i].Time; scTime != nil && *scTime == *activation.Time {
+					continue
+				}
+				writeICSEvent(&b, icsEvent{
+					uid:         fmt.Sprintf("%s-%d-%s@superchain-registry", sc.Superchain, chain.ChainID, activation.Key),
+					stamp:       stamp,
+					start:       *activation.Time,
+					summary:     fmt.Sprintf("%s activation (%s)", activation.Name, chain.Name),
+					description: fmt.Sprintf("%s activation for %s (chain ID %d) in the %s superchain.", activation.Name, chain.Name, chain.ChainID, sc.Superchain),
+				})
+			}
+		}
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type icsEvent struct {
+	uid         string
+	stamp       string
+	start       uint64
+	summary     string
+	description string
+}
+
+func writeICSEvent(b *strings.Builder, ev icsEvent) {
+	writeICSLine(b, "BEGIN:VEVENT")
+	writeICSLine(b, "UID:"+ev.uid)
+	writeICSLine(b, "DTSTAMP:"+ev.stamp)
+	writeICSLine(b, "DTSTART:"+icsTime(ev.start))
+	writeICSLine(b, "DTEND:"+icsTime(ev.start))
+	writeICSLine(b, "SUMMARY:"+icsEscape(ev.summary))
+	writeICSLine(b, "DESCRIPTION:"+icsEscape(ev.description))
+	writeICSLine(b, "END:VEVENT")
+}
+
+// icsMaxLineOctets is the longest a content line may be, excluding the line
+// break, before it has to be folded.
+const icsMaxLineOctets = 75
+
+// writeICSLine terminates lines with CRLF and folds lines longer than 75 octets
+// onto continuation lines starting with a space, as required by RFC 5545. Lines
+// are only folded between UTF-8 characters.
+func writeICSLine(b *strings.Builder, line string) {
+	limit := icsMaxLineOctets
+	for len(line) > limit {
+		n := limit
+		for n > 0 && !utf8.RuneStart(line[n]) {
+			n--
+		}
+		b.WriteString(line[:n])
+		b.WriteString("\r\n ")
+		line = line[n:]
+		// The leading space counts towards the length of continuation lines.
+		limit = icsMaxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+func icsEscape(in string) string {
+	return icsEscaper.Replace(in)
+}
+
+func icsTime(t uint64) string {
+	return time.Unix(int64(t), 0).UTC().Format("20060102T150405Z")
+}
+
+func formatActivationDate(t uint64) string {
+	if t == 0 {
+		return "genesis"
+	}
+	return time.Unix(int64(t), 0).UTC().Format("2006-01-02 15:04:05")
+}
+
+func formatScheduleTime(t uint64) string {
+	return time.Unix(int64(t), 0).UTC().Format(time.RFC3339)
+}
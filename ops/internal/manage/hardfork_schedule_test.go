@@ -0,0 +1,143 @@
+package manage
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/stretchr/testify/require"
+)
+
+// Sepolia Holocene activation in testdata/superchain/configs/sepolia/superchain.toml
+const testHoloceneTime = 1732633200
+
+func TestBuildHardforkSchedule(t *testing.T) {
+	schedule, err := BuildHardforkSchedule(fs.NewDirFS("testdata"), testHoloceneTime-1)
+	require.NoError(t, err)
+	require.Len(t, schedule.Superchains, 1)
+
+	sc := schedule.Superchains[0]
+	require.Equal(t, config.SepoliaSuperchain, sc.Superchain)
+	require.Len(t, sc.Chains, 2)
+
+	statusOf := func(activations []config.HardforkActivation, key string) config.HardforkStatus {
+		for _, activation := range activations {
+			if activation.Key == key {
+				return activation.Status
+			}
+		}
+		t.Fatalf("hardfork %s not found", key)
+		return ""
+	}
+
+	require.Equal(t, config.HardforkActive, statusOf(sc.Hardforks, "granite_time"))
+	require.Equal(t, config.HardforkPending, statusOf(sc.Hardforks, "holocene_time"))
+	require.Equal(t, config.HardforkUnset, statusOf(sc.Hardforks, "isthmus_time"))
+
+	op := sc.Chains[0]
+	require.Equal(t, "op", op.ShortName)
+	require.Equal(t, config.HardforkPending, statusOf(op.Hardforks, "holocene_time"))
+
+	testchain := sc.Chains[1]
+	require.Equal(t, "testchain", testchain.ShortName)
+	require.Equal(t, config.HardforkActive, statusOf(testchain.Hardforks, "granite_time"))
+	require.Equal(t, config.HardforkUnset, statusOf(testchain.Hardforks, "holocene_time"))
+}
+
+func TestActiveHardforks(t *testing.T) {
+	names := func(forks []config.Hardfork) []string {
+		var out []string
+		for _, fork := range forks {
+			out = append(out, fork.Name)
+		}
+		return out
+	}
+
+	forks, err := ActiveHardforks(fs.NewDirFS("testdata"), 11155420, testHoloceneTime)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Canyon", "Delta", "Ecotone", "Fjord", "Granite", "Holocene"}, names(forks))
+
+	forks, err = ActiveHardforks(fs.NewDirFS("testdata"), 11155420, testHoloceneTime-1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Canyon", "Delta", "Ecotone", "Fjord", "Granite"}, names(forks))
+
+	_, err = ActiveHardforks(fs.NewDirFS("testdata"), 1, testHoloceneTime)
+	require.ErrorContains(t, err, "chain with chain ID 1 not found")
+}
+
+func TestHardforkSchedule_Write(t *testing.T) {
+	schedule, err := BuildHardforkSchedule(fs.NewDirFS("testdata"), testHoloceneTime)
+	require.NoError(t, err)
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, schedule.WriteJSON(&buf))
+
+		var decoded HardforkSchedule
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		require.Equal(t, *schedule, decoded)
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, schedule.WriteMarkdown(&buf))
+		require.Contains(t, buf.String(), "## sepolia")
+		require.Contains(t, buf.String(), "| TestChain (1952805748) | ✅ genesis |")
+		require.Contains(t, buf.String(), "✅ 2024-11-26 15:00:00")
+	})
+
+	t.Run("ics", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, schedule.WriteICS(&buf))
+		ics := buf.String()
+		require.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n"))
+		require.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+		// OP Sepolia matches the superchain schedule and TestChain activated
+		// everything at genesis, so only the superchain-wide forks are listed.
+		require.Equal(t, 6, strings.Count(ics, "BEGIN:VEVENT"))
+		require.Contains(t, ics, "UID:sepolia-holocene_time@superchain-registry\r\n")
+		require.Contains(t, ics, "DTSTART:20241126T150000Z\r\n")
+		for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+			require.LessOrEqual(t, len(line), 75, line)
+		}
+	})
+}
+
+func TestWriteICSLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected string
+	}{
+		{
+			name:     "short",
+			line:     "SUMMARY:Holocene",
+			expected: "SUMMARY:Holocene\r\n",
+		},
+		{
+			name:     "exactly 75 octets",
+			line:     strings.Repeat("a", 75),
+			expected: strings.Repeat("a", 75) + "\r\n",
+		},
+		{
+			name:     "folded",
+			line:     strings.Repeat("a", 75+74+1),
+			expected: strings.Repeat("a", 75) + "\r\n " + strings.Repeat("a", 74) + "\r\n a\r\n",
+		},
+		{
+			name:     "multi-byte character at the fold",
+			line:     strings.Repeat("a", 74) + "✅b",
+			expected: strings.Repeat("a", 74) + "\r\n ✅b\r\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			writeICSLine(&b, tt.line)
+			require.Equal(t, tt.expected, b.String())
+		})
+	}
+}
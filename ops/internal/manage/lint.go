@@ -0,0 +1,337 @@
+package manage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	iofs "io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+)
+
+// LintSeverity is how serious a lint finding is. Only errors fail a lint run.
+type LintSeverity string
+
+const (
+	LintError   LintSeverity = "error"
+	LintWarning LintSeverity = "warning"
+)
+
+// LintFinding is a single problem found by a lint rule. File is relative to the
+// root of the registry, and Line is the line of Key in it, or of its closest
+// enclosing table if Key is not in the file. Line is 0 for files that are not
+// TOML, and File is empty for findings that are not about a single file.
+type LintFinding struct {
+	RuleID   string       `json:"ruleId"`
+	Severity LintSeverity `json:"severity"`
+	File     string       `json:"file,omitempty"`
+	Line     int          `json:"line,omitempty"`
+	Key      string       `json:"key,omitempty"`
+	Message  string       `json:"message"`
+}
+
+func (f LintFinding) String() string {
+	var parts []string
+	if f.File != "" {
+		loc := f.File
+		if f.Line > 0 {
+			loc = fmt.Sprintf("%s:%d", f.File, f.Line)
+		}
+		parts = append(parts, loc)
+	}
+	if f.Key != "" {
+		parts = append(parts, f.Key)
+	}
+	parts = append(parts, f.Message)
+	return fmt.Sprintf("%s [%s] %s", f.Severity, f.RuleID, strings.Join(parts, ": "))
+}
+
+// LintRule is a check of the registry. Check reports every problem it finds
+// rather than stopping at the first, and only returns an error if it could not
+// run at all.
+type LintRule struct {
+	ID          string       `json:"id"`
+	Description string       `json:"description"`
+	Severity    LintSeverity `json:"severity"`
+	// Online rules need network access, so they only run when asked for.
+	Online bool                                       `json:"online,omitempty"`
+	Check  func(reg *Registry, r *LintReporter) error `json:"-"`
+}
+
+// SelectLintRules returns the rules in LintRules with the given IDs, in the
+// order of LintRules. If ids is empty, every rule is returned, except for
+// online rules unless online is set.
+func SelectLintRules(ids []string, online bool) ([]LintRule, error) {
+	for _, id := range ids {
+		if !slices.ContainsFunc(LintRules, func(rule LintRule) bool { return rule.ID == id }) {
+			return nil, fmt.Errorf("unknown lint rule %q", id)
+		}
+	}
+
+	var out []LintRule
+	for _, rule := range LintRules {
+		if len(ids) > 0 && !slices.Contains(ids, rule.ID) {
+			continue
+		}
+		if len(ids) == 0 && rule.Online && !online {
+			continue
+		}
+		out = append(out, rule)
+	}
+	return out, nil
+}
+
+// LintReporter collects the findings of a rule.
+type LintReporter struct {
+	rule     LintRule
+	fsys     iofs.FS
+	lines    map[string]map[string]int
+	findings []LintFinding
+}
+
+// Report reports a problem with key in file. The line of the finding is looked
+// up from key.
+func (r *LintReporter) Report(file string, key string, format string, args ...any) {
+	r.ReportLine(file, r.lookupLine(file, key), key, format, args...)
+}
+
+// ReportLine reports a problem with key at a known line of file.
+func (r *LintReporter) ReportLine(file string, line int, key string, format string, args ...any) {
+	r.findings = append(r.findings, LintFinding{
+		RuleID:   r.rule.ID,
+		Severity: r.rule.Severity,
+		File:     file,
+		Line:     line,
+		Key:      key,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// ReportError reports every error joined in err as a separate problem with key
+// in file.
+func (r *LintReporter) ReportError(file string, key string, err error) {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range joined.Unwrap() {
+			r.ReportError(file, key, err)
+		}
+		return
+	}
+	r.Report(file, key, "%v", err)
+}
+
+func (r *LintReporter) lookupLine(file string, key string) int {
+	if file == "" || path.Ext(file) != ".toml" {
+		return 0
+	}
+	lines, ok := r.lines[file]
+	if !ok {
+		data, err := iofs.ReadFile(r.fsys, file)
+		if err == nil {
+			lines = tomlKeyLines(data)
+		}
+		r.lines[file] = lines
+	}
+	return lookupKeyLine(lines, key)
+}
+
+// LintReport is the result of running a set of lint rules.
+type LintReport struct {
+	Rules    []LintRule    `json:"rules"`
+	Findings []LintFinding `json:"findings"`
+}
+
+// LintRegistry runs rules against reg and collects their findings. A rule that
+// fails to run is reported as an error finding of that rule.
+func LintRegistry(reg *Registry, rules []LintRule) *LintReport {
+	report := &LintReport{
+		Rules:    rules,
+		Findings: []LintFinding{},
+	}
+	lines := make(map[string]map[string]int)
+	for _, rule := range rules {
+		r := &LintReporter{
+			rule:  rule,
+			fsys:  reg.FS(),
+			lines: lines,
+		}
+		if err := rule.Check(reg, r); err != nil {
+			r.findings = append(r.findings, LintFinding{
+				RuleID:   rule.ID,
+				Severity: LintError,
+				Message:  fmt.Sprintf("rule failed to run: %v", err),
+			})
+		}
+		report.Findings = append(report.Findings, r.findings...)
+	}
+	return report
+}
+
+// Failed returns whether any finding is an error.
+func (r *LintReport) Failed() bool {
+	return slices.ContainsFunc(r.Findings, func(f LintFinding) bool {
+		return f.Severity == LintError
+	})
+}
+
+// Count returns the number of findings with the given severity.
+func (r *LintReport) Count(severity LintSeverity) int {
+	var n int
+	for _, f := range r.Findings {
+		if f.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// WriteText writes one line per finding, followed by a summary.
+func (r *LintReport) WriteText(w io.Writer) error {
+	for _, f := range r.Findings {
+		if _, err := fmt.Fprintln(w, f.String()); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d errors, %d warnings from %d rules\n", r.Count(LintError), r.Count(LintWarning), len(r.Rules))
+	return err
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *LintReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// WriteSARIF writes the report as a SARIF 2.1.0 log, which GitHub code scanning
+// uses to annotate the lines of the findings. File paths are relative to the
+// root of the repository. Code scanning rejects results without a file, so
+// findings that are not about a single file are located at the directory of
+// the chain configs.
+func (r *LintReport) WriteSARIF(w io.Writer) error {
+	driver := sarifDriver{
+		Name:  "lint_registry",
+		Rules: []sarifRule{},
+	}
+	ruleIndex := make(map[string]int)
+	for i, rule := range r.Rules {
+		ruleIndex[rule.ID] = i
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   rule.ID,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: string(rule.Severity)},
+		})
+	}
+
+	results := []sarifResult{}
+	for _, f := range r.Findings {
+		idx, ok := ruleIndex[f.RuleID]
+		if !ok {
+			return fmt.Errorf("finding of rule %s, which is not in the report", f.RuleID)
+		}
+		result := sarifResult{
+			RuleID:    f.RuleID,
+			RuleIndex: idx,
+			Level:     string(f.Severity),
+			Message:   sarifMessage{Text: f.Message},
+		}
+		uri := f.File
+		if uri == "" {
+			uri = paths.SuperchainConfigsDir(".") + "/"
+		}
+		loc := sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: uri, URIBaseID: "%SRCROOT%"},
+			},
+		}
+		if f.Line > 0 {
+			loc.PhysicalLocation.Region = &sarifRegion{StartLine: f.Line}
+		}
+		if f.Key != "" {
+			loc.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: f.Key, Kind: "member"}}
+		}
+		result.Locations = []sarifLocation{loc}
+		results = append(results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: driver},
+			Results: results,
+		}},
+	})
+}
@@ -0,0 +1,288 @@
+package manage
+
+import (
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"path"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+)
+
+// LintRules are every rule lint_registry can run, in the order they run in.
+var LintRules = []LintRule{
+	{
+		ID:          "schema",
+		Description: "superchain definitions and chain configs, including staged ones, match their JSON Schemas",
+		Severity:    LintError,
+		Check:       lintSchema,
+	},
+	{
+		ID:          "hardfork-order",
+		Description: "hardforks are activated in order",
+		Severity:    LintError,
+		Check:       lintHardforkOrder,
+	},
+	{
+		ID:          "depset",
+		Description: "interop dependencies are symmetric and within the registry and superchain",
+		Severity:    LintError,
+		Check:       lintDepsets,
+	},
+	{
+		ID:          "genesis-integrity",
+		Description: "the genesis of every chain matches its chain config",
+		Severity:    LintError,
+		Check:       lintGenesisIntegrity,
+	},
+	{
+		ID:          "standard-params",
+		Description: "chain configs are within the standard params of their superchain, or allowlisted",
+		Severity:    LintWarning,
+		Check:       lintStandardParams,
+	},
+	{
+		ID:          "fee-scalars",
+		Description: "fee scalars decode and are within the standard params of their superchain",
+		Severity:    LintError,
+		Check:       lintFeeScalars,
+	},
+	{
+		ID:          "altda",
+		Description: "alt-DA configs agree with the data availability type and addresses",
+		Severity:    LintError,
+		Check:       lintAltDA,
+	},
+	{
+		ID:          "staged-uniqueness",
+		Description: "staged chains do not reuse the chain ID or short name of a chain in their superchain",
+		Severity:    LintError,
+		Check:       lintStagedUniqueness,
+	},
+	{
+		ID:          "chainlist",
+		Description: "staged chains match their entry at " + chainListUrl,
+		Severity:    LintError,
+		Online:      true,
+		Check:       lintChainList,
+	},
+}
+
+// standardParamKeys are the chain config keys of the standard params.
+var standardParamKeys = map[string]string{
+	SeqWindowSizeParam: "seq_window_size",
+	BlockTimeParam:     "block_time",
+	GasLimitParam:      "genesis.system_config.gasLimit",
+}
+
+func chainConfigName(cfg DiskChainConfig) string {
+	return paths.ChainConfig(".", cfg.Superchain, cfg.ShortName)
+}
+
+func stagedChainConfigName(cfg *config.StagedChain) string {
+	return path.Join(paths.StagingDir("."), cfg.ShortName+".toml")
+}
+
+// stagedChainConfigs returns the staged chain configs of reg, or none if nothing
+// is staged.
+func stagedChainConfigs(reg *Registry) ([]*config.StagedChain, error) {
+	cfgs, err := StagedChainConfigsFS(reg.FS())
+	if errors.Is(err, ErrNoStagedConfig) {
+		return nil, nil
+	}
+	return cfgs, err
+}
+
+func lintSchema(reg *Registry, r *LintReporter) error {
+	targets := []struct {
+		root    string
+		matcher paths.CollectorMatcher
+		schema  *config.Schema
+	}{
+		{paths.SuperchainConfigsDir("."), paths.SuperchainDefinitionMatcher(), config.SuperchainDefinitionSchema()},
+		{paths.SuperchainConfigsDir("."), paths.ChainConfigMatcher(), config.ChainSchema()},
+		{paths.StagingDir("."), paths.SuperchainDefinitionMatcher(), config.SuperchainDefinitionSchema()},
+		{paths.StagingDir("."), paths.ChainConfigMatcher(), config.StagedChainSchema()},
+	}
+
+	fsys := reg.FS()
+	for _, t := range targets {
+		err := iofs.WalkDir(fsys, t.root, func(name string, d iofs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !t.matcher(name) {
+				return err
+			}
+			data, err := iofs.ReadFile(fsys, name)
+			if err != nil {
+				return err
+			}
+			for _, schemaErr := range validateTOMLSchema(name, data, t.schema) {
+				r.ReportLine(schemaErr.File, schemaErr.Line, schemaErr.Key, "%s", schemaErr.Message)
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, iofs.ErrNotExist) {
+			return fmt.Errorf("failed to validate configs in %s: %w", t.root, err)
+		}
+	}
+	return nil
+}
+
+func lintHardforkOrder(reg *Registry, r *LintReporter) error {
+	for _, superchain := range reg.Superchains() {
+		def, _ := reg.SuperchainDefinition(superchain)
+		if err := def.Hardforks.ValidateOrder(); err != nil {
+			r.Report(paths.SuperchainConfig(".", superchain), "hardforks", "%v", err)
+		}
+	}
+	for _, cfg := range reg.Chains() {
+		if err := cfg.Config.Hardforks.ValidateOrder(); err != nil {
+			r.Report(chainConfigName(cfg), "hardforks", "%v", err)
+		}
+	}
+
+	staged, err := stagedChainConfigs(reg)
+	if err != nil {
+		return err
+	}
+	for _, cfg := range staged {
+		if err := cfg.Hardforks.ValidateOrder(); err != nil {
+			r.Report(stagedChainConfigName(cfg), "hardforks", "%v", err)
+		}
+	}
+	return nil
+}
+
+func lintDepsets(reg *Registry, r *LintReporter) error {
+	graph, err := NewDependencyGraph(reg.Chains())
+	if err != nil {
+		return fmt.Errorf("failed to build dependency graph: %w", err)
+	}
+
+	report := func(edge DependencyEdge, format string, args ...any) {
+		from, _ := graph.Node(edge.From)
+		cfg, _ := reg.ChainByID(edge.From)
+		r.Report(chainConfigName(cfg), fmt.Sprintf("interop.dependencies.%d", edge.To), "chain %d (%s/%s) "+format,
+			append([]any{from.ChainID, from.Superchain, from.ShortName}, args...)...)
+	}
+	for _, edge := range graph.AsymmetricEdges() {
+		report(edge, "depends on chain %d, but not the other way around", edge.To)
+	}
+	for _, edge := range graph.DanglingEdges() {
+		report(edge, "depends on chain %d, which is not in the registry", edge.To)
+	}
+	for _, edge := range graph.Edges() {
+		from, _ := graph.Node(edge.From)
+		to, ok := graph.Node(edge.To)
+		if ok && to.Superchain != from.Superchain {
+			report(edge, "depends on chain %d of superchain %s", edge.To, to.Superchain)
+		}
+	}
+	return nil
+}
+
+func lintGenesisIntegrity(reg *Registry, r *LintReporter) error {
+	for _, cfg := range reg.Chains() {
+		if IsLegacyGenesis(cfg.Superchain, cfg.ShortName) {
+			continue
+		}
+
+		genesis, err := ReadSuperchainGenesisFS(reg.FS(), cfg.Superchain, cfg.ShortName)
+		if err != nil {
+			r.Report(paths.GenesisFile(".", cfg.Superchain, cfg.ShortName), "", "%v", err)
+			continue
+		}
+		if err := ValidateGenesisIntegrity(cfg.Config, genesis); err != nil {
+			r.Report(chainConfigName(cfg), "genesis", "%v", err)
+		}
+	}
+	return nil
+}
+
+func lintStandardParams(reg *Registry, r *LintReporter) error {
+	allowlist, err := ReadStandardParamsAllowlist(reg.FS())
+	if err != nil {
+		return fmt.Errorf("failed to read standard params allowlist: %w", err)
+	}
+	results, err := CheckStandardParams(reg.FS(), reg.Chains(), allowlist)
+	if err != nil {
+		return err
+	}
+
+	for _, res := range results {
+		for _, check := range res.Deviations() {
+			if check.Allowed {
+				continue
+			}
+			r.Report(paths.ChainConfig(".", res.Superchain, res.ShortName), standardParamKeys[check.Param],
+				"%s is %d, want %d-%d", check.Param, check.Value, check.Range[0], check.Range[1])
+		}
+	}
+	return nil
+}
+
+func lintFeeScalars(reg *Registry, r *LintReporter) error {
+	for _, cfg := range reg.Chains() {
+		params, ok := StandardConfigParams(cfg.Superchain)
+		if !ok {
+			continue
+		}
+		if err := ValidateFeeScalars(cfg.Config, params.GasPriceOracle.Ecotone); err != nil {
+			r.ReportError(chainConfigName(cfg), "genesis.system_config.scalar", err)
+		}
+	}
+	return nil
+}
+
+func lintAltDA(reg *Registry, r *LintReporter) error {
+	for _, cfg := range reg.Chains() {
+		if err := ValidateAltDA(cfg.Config); err != nil {
+			r.ReportError(chainConfigName(cfg), "alt_da", err)
+		}
+	}
+	return nil
+}
+
+func lintStagedUniqueness(reg *Registry, r *LintReporter) error {
+	staged, err := stagedChainConfigs(reg)
+	if err != nil {
+		return err
+	}
+	for _, cfg := range staged {
+		err := ValidateUniqueness(cfg, reg.ChainsBySuperchain(cfg.Superchain))
+		switch {
+		case errors.Is(err, ErrDuplicateChainID):
+			r.Report(stagedChainConfigName(cfg), "chain_id", "%v", err)
+		case err != nil:
+			r.Report(stagedChainConfigName(cfg), "", "%v", err)
+		}
+	}
+	return nil
+}
+
+func lintChainList(reg *Registry, r *LintReporter) error {
+	staged, err := stagedChainConfigs(reg)
+	if err != nil || len(staged) == 0 {
+		return err
+	}
+
+	globalChainData, err := FetchGlobalChainIDs()
+	if err != nil {
+		return fmt.Errorf("failed to fetch global chain IDs: %w", err)
+	}
+	for _, cfg := range staged {
+		file := stagedChainConfigName(cfg)
+		entry, ok := globalChainData[cfg.ChainID]
+		if !ok {
+			r.Report(file, "chain_id", "chain ID %d not found in global chain data", cfg.ChainID)
+			continue
+		}
+		if entry.ShortName != cfg.ShortName {
+			r.Report(file, "", "short name %s does not match global chain data short name %s", cfg.ShortName, entry.ShortName)
+		}
+		if entry.Name != cfg.Name {
+			r.Report(file, "name", "name %s does not match global chain data name %s", cfg.Name, entry.Name)
+		}
+	}
+	return nil
+}
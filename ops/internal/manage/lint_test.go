@@ -0,0 +1,179 @@
+package manage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	iofs "io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintRegistry(t *testing.T) {
+	fsys := copyToMemFS(t, os.DirFS("testdata"))
+	testchain := paths.ChainConfig(".", config.SepoliaSuperchain, "testchain")
+	data, err := iofs.ReadFile(fsys, testchain)
+	require.NoError(t, err)
+	require.Equal(t, 1, strings.Count(string(data), "fjord_time = 0 #"))
+	data = []byte(strings.Replace(string(data), "fjord_time = 0 #", "fjord_time = 5 #", 1))
+	data = append(data, "\n[interop]\n  dependencies.\"1952805748\" = {}\n  dependencies.\"99\" = {}\n"...)
+	require.NoError(t, fsys.WriteFile(testchain, data, 0o644))
+	lineOf := func(data []byte, s string) int {
+		idx := bytes.Index(data, []byte(s))
+		require.NotEqual(t, -1, idx)
+		return bytes.Count(data[:idx], []byte("\n")) + 1
+	}
+
+	staged := []byte("name = \"Duplicate\"\nchain_id = 11155420\nsuperchain = \"sepolia\"\n")
+	require.NoError(t, fsys.WriteFile(paths.StagingDir(".")+"/dup.toml", staged, 0o644))
+
+	reg, err := LoadRegistryFS(fsys, false)
+	require.NoError(t, err)
+
+	// Rules are pluggable, and a rule that fails to run is reported.
+	rules, err := SelectLintRules([]string{"hardfork-order", "depset", "staged-uniqueness"}, false)
+	require.NoError(t, err)
+	rules = append(rules,
+		LintRule{
+			ID:       "custom",
+			Severity: LintWarning,
+			Check: func(reg *Registry, r *LintReporter) error {
+				r.Report(testchain, "genesis.system_config.gasLimit", "gas limit is %d", 0)
+				return nil
+			},
+		},
+		LintRule{
+			ID:       "broken",
+			Severity: LintWarning,
+			Check: func(reg *Registry, r *LintReporter) error {
+				return errors.New("boom")
+			},
+		},
+	)
+
+	report := LintRegistry(reg, rules)
+	require.Len(t, report.Findings, 5)
+	require.Equal(t, LintFinding{
+		RuleID:   "hardfork-order",
+		Severity: LintError,
+		File:     testchain,
+		Line:     lineOf(data, "[hardforks]"),
+		Key:      "hardforks",
+		Message:  report.Findings[0].Message,
+	}, report.Findings[0])
+	require.Equal(t, LintFinding{
+		RuleID:   "depset",
+		Severity: LintError,
+		File:     testchain,
+		Line:     lineOf(data, "dependencies.\"99\""),
+		Key:      "interop.dependencies.99",
+		Message:  "chain 1952805748 (sepolia/testchain) depends on chain 99, which is not in the registry",
+	}, report.Findings[1])
+	require.Equal(t, "staged-uniqueness", report.Findings[2].RuleID)
+	require.Equal(t, paths.StagingDir(".")+"/dup.toml", report.Findings[2].File)
+	require.Equal(t, 2, report.Findings[2].Line)
+	require.Contains(t, report.Findings[2].Message, ErrDuplicateChainID.Error())
+	require.Equal(t, LintFinding{
+		RuleID:   "custom",
+		Severity: LintWarning,
+		File:     testchain,
+		Line:     lineOf(data, "gasLimit = 0"),
+		Key:      "genesis.system_config.gasLimit",
+		Message:  "gas limit is 0",
+	}, report.Findings[3])
+	require.Equal(t, LintFinding{
+		RuleID:   "broken",
+		Severity: LintError,
+		Message:  "rule failed to run: boom",
+	}, report.Findings[4])
+	require.True(t, report.Failed())
+	require.Equal(t, 4, report.Count(LintError))
+
+	var text bytes.Buffer
+	require.NoError(t, report.WriteText(&text))
+	require.Contains(t, text.String(), "warning [custom] "+testchain+":"+strconv.Itoa(report.Findings[3].Line)+": genesis.system_config.gasLimit: gas limit is 0\n")
+	require.Contains(t, text.String(), "error [broken] rule failed to run: boom\n")
+	require.Contains(t, text.String(), "4 errors, 1 warnings from 5 rules\n")
+
+	var js bytes.Buffer
+	require.NoError(t, report.WriteJSON(&js))
+	var decoded LintReport
+	require.NoError(t, json.Unmarshal(js.Bytes(), &decoded))
+	require.Equal(t, report.Findings, decoded.Findings)
+
+	var sarif bytes.Buffer
+	require.NoError(t, report.WriteSARIF(&sarif))
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI       *string `json:"uri"`
+							URIBaseID string  `json:"uriBaseId"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(sarif.Bytes(), &log))
+	require.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	require.Len(t, log.Runs[0].Tool.Driver.Rules, 5)
+	results := log.Runs[0].Results
+	require.Len(t, results, 5)
+	require.Equal(t, "depset", results[1].RuleID)
+	require.Equal(t, 1, results[1].RuleIndex)
+	require.Equal(t, "error", results[1].Level)
+	require.Equal(t, testchain, *results[1].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.Equal(t, report.Findings[1].Line, results[1].Locations[0].PhysicalLocation.Region.StartLine)
+	// Findings that are not about a single file are located at the configs.
+	require.Len(t, results[4].Locations, 1)
+	require.Equal(t, "superchain/configs/", *results[4].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.Equal(t, "%SRCROOT%", results[4].Locations[0].PhysicalLocation.ArtifactLocation.URIBaseID)
+}
+
+func TestSelectLintRules(t *testing.T) {
+	rules, err := SelectLintRules(nil, false)
+	require.NoError(t, err)
+	for _, rule := range rules {
+		require.False(t, rule.Online)
+	}
+	online, err := SelectLintRules(nil, true)
+	require.NoError(t, err)
+	require.Len(t, online, len(LintRules))
+
+	rules, err = SelectLintRules([]string{"chainlist", "schema"}, false)
+	require.NoError(t, err)
+	require.Equal(t, "schema", rules[0].ID)
+	require.Equal(t, "chainlist", rules[1].ID)
+
+	// Deviations from the standard params are advisory.
+	rules, err = SelectLintRules([]string{"standard-params"}, false)
+	require.NoError(t, err)
+	require.Equal(t, LintWarning, rules[0].Severity)
+
+	_, err = SelectLintRules([]string{"missing"}, false)
+	require.ErrorContains(t, err, "unknown lint rule")
+}
@@ -0,0 +1,211 @@
+package manage
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Migration is a numbered change to the chain config format. Apply must be
+// idempotent: applying it to a config that already has the new format must not
+// change anything.
+type Migration struct {
+	Version     uint64
+	Description string
+	Apply       func(cfg *config.Chain) error
+}
+
+// Migrations are every chain config migration, in order. A config's
+// schema_version is the version of the last migration applied to it. New
+// migrations are appended with the next version; existing ones must never be
+// changed or reordered, since configs in open PRs may still need them.
+var Migrations = []Migration{
+	{
+		Version:     1,
+		Description: "keep only the ProxyAdminOwner role; the other roles live in addresses.json",
+		Apply: func(cfg *config.Chain) error {
+			cfg.Roles = config.Roles{ProxyAdminOwner: cfg.Roles.ProxyAdminOwner}
+			return nil
+		},
+	},
+	{
+		Version:     2,
+		Description: "keep only the essential addresses; the other addresses live in addresses.json",
+		Apply: func(cfg *config.Chain) error {
+			cfg.Addresses = config.Addresses{
+				L1StandardBridgeProxy:   cfg.Addresses.L1StandardBridgeProxy,
+				OptimismPortalProxy:     cfg.Addresses.OptimismPortalProxy,
+				SystemConfigProxy:       cfg.Addresses.SystemConfigProxy,
+				DisputeGameFactoryProxy: cfg.Addresses.DisputeGameFactoryProxy,
+				DAChallengeAddress:      cfg.Addresses.DAChallengeAddress,
+			}
+			return nil
+		},
+	},
+	{
+		Version:     3,
+		Description: "drop pectra_blob_schedule_time at or before genesis, where the blob schedule fix affects no block",
+		Apply: func(cfg *config.Chain) error {
+			if t := cfg.Hardforks.PectraBlobScheduleTime; t != nil && uint64(*t) <= cfg.Genesis.L2Time {
+				cfg.Hardforks.PectraBlobScheduleTime = nil
+			}
+			return nil
+		},
+	},
+}
+
+// LatestSchemaVersion is the schema version of configs with every migration
+// applied. New configs are created at this version.
+func LatestSchemaVersion() uint64 {
+	return Migrations[len(Migrations)-1].Version
+}
+
+// ValidateSchemaVersion returns an error if cfg does not have every migration
+// applied.
+func ValidateSchemaVersion(cfg *config.Chain) error {
+	if cfg.SchemaVersion != LatestSchemaVersion() {
+		return fmt.Errorf("schema version %d is not the latest version %d, run migrate-configs", cfg.SchemaVersion, LatestSchemaVersion())
+	}
+	return nil
+}
+
+// MigrateChain applies every migration newer than cfg's schema version to cfg,
+// and returns the migrations it applied.
+func MigrateChain(cfg *config.Chain) ([]Migration, error) {
+	if cfg.SchemaVersion > LatestSchemaVersion() {
+		return nil, fmt.Errorf("schema version %d is newer than the latest known version %d", cfg.SchemaVersion, LatestSchemaVersion())
+	}
+
+	var applied []Migration
+	for _, migration := range Migrations {
+		if migration.Version <= cfg.SchemaVersion {
+			continue
+		}
+		if err := migration.Apply(cfg); err != nil {
+			return applied, fmt.Errorf("migration %d failed: %w", migration.Version, err)
+		}
+		cfg.SchemaVersion = migration.Version
+		applied = append(applied, migration)
+	}
+	return applied, nil
+}
+
+// ConfigMigration describes the migration of a single config file. File is
+// relative to the repository root, and Diff is a unified diff of the file.
+type ConfigMigration struct {
+	File        string
+	FromVersion uint64
+	ToVersion   uint64
+	Diff        string
+}
+
+// MigrateConfigs migrates every staged and registered chain config in the
+// registry at wd, and returns the configs that had pending migrations. Migrated
+// configs are re-encoded in the canonical format, and are only written if
+// write is true.
+func MigrateConfigs(wd string, write bool) ([]ConfigMigration, error) {
+	var out []ConfigMigration
+	for _, root := range []string{paths.StagingDir(wd), paths.SuperchainConfigsDir(wd)} {
+		exists, err := fs.DirExists(root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if %s exists: %w", root, err)
+		}
+		if !exists {
+			continue
+		}
+
+		files, err := paths.CollectFiles(root, paths.ChainConfigMatcher())
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect files in %s: %w", root, err)
+		}
+		staged := root == paths.StagingDir(wd)
+		for _, file := range files {
+			migration, err := migrateConfigFile(wd, file, staged, write)
+			if err != nil {
+				return nil, fmt.Errorf("failed to migrate %s: %w", file, err)
+			}
+			if migration != nil {
+				out = append(out, *migration)
+			}
+		}
+	}
+	return out, nil
+}
+
+func migrateConfigFile(wd string, file string, staged bool, write bool) (*ConfigMigration, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// Staged configs have extra fields around the chain config, which must be
+	// preserved when re-encoding.
+	var (
+		cfg   any
+		chain *config.Chain
+	)
+	if staged {
+		stagedCfg := new(config.StagedChain)
+		cfg, chain = stagedCfg, &stagedCfg.Chain
+	} else {
+		chain = new(config.Chain)
+		cfg = chain
+	}
+	if err := toml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal TOML: %w", err)
+	}
+
+	fromVersion := chain.SchemaVersion
+	applied, err := MigrateChain(chain)
+	if err != nil {
+		return nil, err
+	}
+	if len(applied) == 0 {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to marshal TOML: %w", err)
+	}
+	migrated := buf.Bytes()
+
+	rel, err := filepath.Rel(wd, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relative path: %w", err)
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(data)),
+		B:        difflib.SplitLines(string(migrated)),
+		FromFile: "a/" + rel,
+		ToFile:   "b/" + rel,
+		Context:  3,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff: %w", err)
+	}
+
+	if write {
+		stat, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat file: %w", err)
+		}
+		if err := fs.AtomicWrite(file, stat.Mode().Perm(), migrated); err != nil {
+			return nil, fmt.Errorf("failed to write file: %w", err)
+		}
+	}
+
+	return &ConfigMigration{
+		File:        rel,
+		FromVersion: fromVersion,
+		ToVersion:   chain.SchemaVersion,
+		Diff:        diff,
+	}, nil
+}
@@ -0,0 +1,172 @@
+package manage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func unmigratedChain() *config.Chain {
+	addr := func(b byte) *config.ChecksummedAddress {
+		return config.NewChecksummedAddress(common.Address{b})
+	}
+	return &config.Chain{
+		Name:    "Test",
+		ChainID: 1234,
+		Roles: config.Roles{
+			SystemConfigOwner: addr(1),
+			ProxyAdminOwner:   addr(2),
+			Guardian:          addr(3),
+		},
+		Addresses: config.Addresses{
+			AddressManager:          addr(4),
+			L1StandardBridgeProxy:   addr(5),
+			OptimismPortalProxy:     addr(6),
+			SystemConfigProxy:       addr(7),
+			DisputeGameFactoryProxy: addr(8),
+			ProxyAdmin:              addr(9),
+		},
+	}
+}
+
+func TestMigrations(t *testing.T) {
+	for i, migration := range Migrations {
+		require.Equal(t, uint64(i+1), migration.Version, "migrations must be numbered sequentially from 1")
+		require.NotEmpty(t, migration.Description)
+
+		once := unmigratedChain()
+		require.NoError(t, migration.Apply(once))
+		twice := unmigratedChain()
+		require.NoError(t, migration.Apply(twice))
+		require.NoError(t, migration.Apply(twice))
+		require.Equal(t, once, twice, "migration %d is not idempotent", migration.Version)
+	}
+}
+
+func TestMigrateChain(t *testing.T) {
+	cfg := unmigratedChain()
+	expected := unmigratedChain()
+	expected.SchemaVersion = LatestSchemaVersion()
+	expected.Roles = config.Roles{ProxyAdminOwner: cfg.Roles.ProxyAdminOwner}
+	expected.Addresses = config.Addresses{
+		L1StandardBridgeProxy:   cfg.Addresses.L1StandardBridgeProxy,
+		OptimismPortalProxy:     cfg.Addresses.OptimismPortalProxy,
+		SystemConfigProxy:       cfg.Addresses.SystemConfigProxy,
+		DisputeGameFactoryProxy: cfg.Addresses.DisputeGameFactoryProxy,
+	}
+
+	applied, err := MigrateChain(cfg)
+	require.NoError(t, err)
+	require.Len(t, applied, len(Migrations))
+	require.Equal(t, expected, cfg)
+
+	applied, err = MigrateChain(cfg)
+	require.NoError(t, err)
+	require.Empty(t, applied)
+
+	cfg = unmigratedChain()
+	cfg.SchemaVersion = 1
+	applied, err = MigrateChain(cfg)
+	require.NoError(t, err)
+	require.Len(t, applied, 2)
+	require.Equal(t, uint64(2), applied[0].Version)
+	require.Equal(t, cfg.Roles, unmigratedChain().Roles, "migration 1 must not be re-applied")
+
+	// The Pectra blob schedule fix is kept for chains launched before it.
+	cfg = unmigratedChain()
+	cfg.SchemaVersion = 2
+	cfg.Genesis.L2Time = 100
+	cfg.Hardforks.PectraBlobScheduleTime = config.NewHardforkTime(101)
+	_, err = MigrateChain(cfg)
+	require.NoError(t, err)
+	require.Equal(t, config.NewHardforkTime(101), cfg.Hardforks.PectraBlobScheduleTime)
+	cfg.SchemaVersion = 2
+	cfg.Hardforks.PectraBlobScheduleTime = config.NewHardforkTime(100)
+	_, err = MigrateChain(cfg)
+	require.NoError(t, err)
+	require.Nil(t, cfg.Hardforks.PectraBlobScheduleTime)
+
+	cfg.SchemaVersion = LatestSchemaVersion() + 1
+	_, err = MigrateChain(cfg)
+	require.ErrorContains(t, err, "is newer than the latest known version")
+}
+
+func TestValidateSchemaVersion(t *testing.T) {
+	cfg := unmigratedChain()
+	require.ErrorContains(t, ValidateSchemaVersion(cfg), "schema version 0 is not the latest version")
+
+	_, err := MigrateChain(cfg)
+	require.NoError(t, err)
+	require.NoError(t, ValidateSchemaVersion(cfg))
+}
+
+func TestMigrateConfigs(t *testing.T) {
+	wd := t.TempDir()
+	writeTOML := func(p string, v any) {
+		require.NoError(t, paths.EnsureDir(filepath.Dir(p)))
+		data, err := toml.Marshal(v)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(p, data, 0o644))
+	}
+
+	chainFile := paths.ChainConfig(wd, "sepolia", "test")
+	writeTOML(chainFile, unmigratedChain())
+	writeTOML(paths.SuperchainConfig(wd, "sepolia"), config.SuperchainDefinition{Name: "Sepolia"})
+	stagedFile := filepath.Join(paths.StagingDir(wd), "staged.toml")
+	writeTOML(stagedFile, config.StagedChain{
+		Chain:      *unmigratedChain(),
+		Superchain: "sepolia",
+	})
+	original, err := os.ReadFile(chainFile)
+	require.NoError(t, err)
+
+	migrations, err := MigrateConfigs(wd, false)
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	require.Equal(t, ".staging/staged.toml", migrations[0].File)
+	require.Equal(t, "superchain/configs/sepolia/test.toml", migrations[1].File)
+	for _, migration := range migrations {
+		require.Equal(t, uint64(0), migration.FromVersion)
+		require.Equal(t, LatestSchemaVersion(), migration.ToVersion)
+		require.Contains(t, migration.Diff, "+++ b/"+migration.File)
+		require.Contains(t, migration.Diff, "+schema_version = 3\n")
+		require.Contains(t, migration.Diff, "-  Guardian = ")
+		require.Contains(t, migration.Diff, "-  AddressManager = ")
+		require.NotContains(t, migration.Diff, "-  ProxyAdminOwner = ")
+	}
+	require.NotContains(t, migrations[0].Diff, "-superchain = ")
+	data, err := os.ReadFile(chainFile)
+	require.NoError(t, err)
+	require.Equal(t, original, data, "dry run must not write")
+
+	migrations, err = MigrateConfigs(wd, true)
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	var staged config.StagedChain
+	require.NoError(t, paths.ReadTOMLFileStrict(stagedFile, &staged))
+	require.Equal(t, LatestSchemaVersion(), staged.SchemaVersion)
+	require.Equal(t, "sepolia", staged.Superchain)
+	require.Nil(t, staged.Roles.Guardian)
+
+	migrations, err = MigrateConfigs(wd, true)
+	require.NoError(t, err)
+	require.Empty(t, migrations)
+}
+
+func TestMigrateConfigsRepo(t *testing.T) {
+	rootDir, err := paths.FindRepoRoot()
+	require.NoError(t, err)
+
+	migrations, err := MigrateConfigs(rootDir, false)
+	require.NoError(t, err)
+	for _, migration := range migrations {
+		require.Failf(t, "pending migration", "%s:\n%s", migration.File, migration.Diff)
+	}
+}
@@ -0,0 +1,186 @@
+package manage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-fetcher/pkg/fetcher/fetch/script"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/l1block"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// OnchainSnapshot is the onchain config of a chain, as fetched from its L1
+// contracts. Snapshots are stored in the registry so that codegen can run
+// without access to the L1.
+type OnchainSnapshot struct {
+	ChainID uint64             `json:"chainId"`
+	L1Block l1block.Ref        `json:"l1Block"`
+	Config  script.ChainConfig `json:"config"`
+	// NativeCurrency is only set for chains with a custom gas paying token.
+	NativeCurrency *config.NativeCurrency `json:"nativeCurrency,omitempty"`
+}
+
+// sameContents returns whether s and other hold the same onchain data, even if
+// they were read at different L1 blocks.
+func (s *OnchainSnapshot) sameContents(other *OnchainSnapshot) (bool, error) {
+	a, err := json.Marshal(OnchainSnapshot{ChainID: s.ChainID, Config: s.Config, NativeCurrency: s.NativeCurrency})
+	if err != nil {
+		return false, err
+	}
+	b, err := json.Marshal(OnchainSnapshot{ChainID: other.ChainID, Config: other.Config, NativeCurrency: other.NativeCurrency})
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(a, b), nil
+}
+
+// ReadOnchainSnapshot reads the snapshot of a chain from fsys.
+func ReadOnchainSnapshot(fsys iofs.FS, chainID uint64) (*OnchainSnapshot, error) {
+	var snapshot OnchainSnapshot
+	if err := paths.ReadJSONFileFS(fsys, paths.OnchainSnapshotFile(".", chainID), &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to read onchain snapshot of chain %d: %w", chainID, err)
+	}
+	if snapshot.ChainID != chainID {
+		return nil, fmt.Errorf("onchain snapshot of chain %d is for chain %d", chainID, snapshot.ChainID)
+	}
+	return &snapshot, nil
+}
+
+// ReadOnchainSnapshots reads the snapshots of the chains FetchChains would fetch
+// for the same arguments. Every chain must have a snapshot.
+func ReadOnchainSnapshots(reg *Registry, chainIds []uint64, superchains []config.Superchain) (map[uint64]*OnchainSnapshot, error) {
+	chainsBySuperchain, err := collectChainsBySuperchain(reg, chainIds, superchains)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[uint64]*OnchainSnapshot)
+	for _, chains := range chainsBySuperchain {
+		for _, cfg := range chains {
+			snapshot, err := ReadOnchainSnapshot(reg.FS(), cfg.Config.ChainID)
+			if errors.Is(err, iofs.ErrNotExist) {
+				return nil, fmt.Errorf("%w, run refresh-onchain first", err)
+			}
+			if err != nil {
+				return nil, err
+			}
+			out[cfg.Config.ChainID] = snapshot
+		}
+	}
+	return out, nil
+}
+
+// WriteOnchainSnapshots writes snapshots to out, and returns the IDs of the
+// chains whose snapshot was written. Existing snapshots with the same onchain
+// data are kept as they are, so that refreshing only changes the snapshots of
+// chains whose onchain config changed. Kept snapshots replace the ones in
+// snapshots, so that their L1 block is the one recorded by later codegen.
+func WriteOnchainSnapshots(out fs.WriteFS, snapshots map[uint64]*OnchainSnapshot) ([]uint64, error) {
+	var updated []uint64
+	for _, chainID := range slices.Sorted(maps.Keys(snapshots)) {
+		snapshot := snapshots[chainID]
+		existing, err := ReadOnchainSnapshot(out, chainID)
+		if err != nil && !errors.Is(err, iofs.ErrNotExist) {
+			return nil, err
+		}
+		if existing != nil {
+			same, err := existing.sameContents(snapshot)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compare onchain snapshot of chain %d: %w", chainID, err)
+			}
+			if same {
+				snapshots[chainID] = existing
+				continue
+			}
+		}
+
+		data, err := json.MarshalIndent(snapshot, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal onchain snapshot of chain %d: %w", chainID, err)
+		}
+		if err := out.WriteFile(paths.OnchainSnapshotFile(".", chainID), append(data, '\n'), 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write onchain snapshot of chain %d: %w", chainID, err)
+		}
+		updated = append(updated, chainID)
+	}
+	return updated, nil
+}
+
+// PruneOnchainSnapshots removes the snapshots in out of chains that are not in
+// reg, and returns their IDs.
+func PruneOnchainSnapshots(out fs.WriteFS, reg *Registry) ([]uint64, error) {
+	dir := paths.OnchainSnapshotsDir(".")
+	entries, err := iofs.ReadDir(out, dir)
+	if errors.Is(err, iofs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read onchain snapshots: %w", err)
+	}
+
+	var removed []uint64
+	for _, entry := range entries {
+		idStr, ok := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !ok {
+			continue
+		}
+		chainID, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		if _, ok := reg.ChainByID(chainID); ok {
+			continue
+		}
+		if err := out.Remove(path.Join(dir, entry.Name())); err != nil {
+			return nil, fmt.Errorf("failed to remove onchain snapshot of chain %d: %w", chainID, err)
+		}
+		removed = append(removed, chainID)
+	}
+	return removed, nil
+}
+
+// PinnedL1Blocks returns the L1 blocks of the snapshots of chains whose
+// superchain has a block in blocks, as passed to FetchChains. Chains fetched at
+// the latest block are left out, since recording it would change the generated
+// files on every run.
+func PinnedL1Blocks(reg *Registry, snapshots map[uint64]*OnchainSnapshot, blocks map[config.Superchain]l1block.Spec) map[uint64]l1block.Ref {
+	out := make(map[uint64]l1block.Ref)
+	for chainID, snapshot := range snapshots {
+		cfg, ok := reg.ChainByID(chainID)
+		if ok && blocks[cfg.Superchain] != "" {
+			out[chainID] = snapshot.L1Block
+		}
+	}
+	return out
+}
+
+// NewSnapshotCodegenSyncer creates a CodegenSyncer that syncs the chains of
+// snapshots, including the gas paying token metadata of their native currency
+// and the L1 block they were read at. Callers syncing freshly fetched snapshots
+// should only record pinned blocks, by passing WithL1Blocks with the result of
+// PinnedL1Blocks.
+func NewSnapshotCodegenSyncer(lgr log.Logger, reg *Registry, snapshots map[uint64]*OnchainSnapshot, opts ...CodegenSyncerOption) (*CodegenSyncer, error) {
+	chainCfgs := make(map[uint64]script.ChainConfig, len(snapshots))
+	nativeCurrencies := make(map[uint64]*config.NativeCurrency)
+	l1Blocks := make(map[uint64]l1block.Ref, len(snapshots))
+	for chainID, snapshot := range snapshots {
+		chainCfgs[chainID] = snapshot.Config
+		l1Blocks[chainID] = snapshot.L1Block
+		if snapshot.NativeCurrency != nil {
+			nativeCurrencies[chainID] = snapshot.NativeCurrency
+		}
+	}
+	opts = append([]CodegenSyncerOption{WithNativeCurrencies(nativeCurrencies), WithL1Blocks(l1Blocks)}, opts...)
+	return NewCodegenSyncer(lgr, reg, chainCfgs, opts...)
+}
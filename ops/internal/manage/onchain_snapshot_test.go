@@ -0,0 +1,157 @@
+package manage
+
+import (
+	iofs "io/fs"
+	"maps"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-fetcher/pkg/fetcher/fetch/script"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/l1block"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteOnchainSnapshots(t *testing.T) {
+	out := fs.NewMemFS()
+	snapshot := func(block uint64, permissionless bool) *OnchainSnapshot {
+		return &OnchainSnapshot{
+			ChainID: 10,
+			L1Block: l1block.Ref{Number: block, Hash: common.Hash{byte(block)}},
+			Config: script.ChainConfig{
+				FaultProofStatus: &script.FaultProofStatus{Permissioned: true, Permissionless: permissionless},
+			},
+			NativeCurrency: &config.NativeCurrency{Name: "Token", Symbol: "TKN", Decimals: 18},
+		}
+	}
+
+	updated, err := WriteOnchainSnapshots(out, map[uint64]*OnchainSnapshot{10: snapshot(1, false)})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{10}, updated)
+	got, err := ReadOnchainSnapshot(out, 10)
+	require.NoError(t, err)
+	require.Equal(t, snapshot(1, false), got)
+
+	// The same onchain data at a later block keeps the existing snapshot, which
+	// also replaces the fetched one.
+	fetched := map[uint64]*OnchainSnapshot{10: snapshot(2, false)}
+	updated, err = WriteOnchainSnapshots(out, fetched)
+	require.NoError(t, err)
+	require.Empty(t, updated)
+	got, err = ReadOnchainSnapshot(out, 10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), got.L1Block.Number)
+	require.Equal(t, got, fetched[10])
+
+	updated, err = WriteOnchainSnapshots(out, map[uint64]*OnchainSnapshot{10: snapshot(3, true)})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{10}, updated)
+	got, err = ReadOnchainSnapshot(out, 10)
+	require.NoError(t, err)
+	require.Equal(t, snapshot(3, true), got)
+
+	_, err = ReadOnchainSnapshot(out, 11)
+	require.ErrorIs(t, err, iofs.ErrNotExist)
+	data, err := iofs.ReadFile(out, paths.OnchainSnapshotFile(".", 10))
+	require.NoError(t, err)
+	require.NoError(t, out.WriteFile(paths.OnchainSnapshotFile(".", 11), data, 0o644))
+	_, err = ReadOnchainSnapshot(out, 11)
+	require.ErrorContains(t, err, "is for chain 10")
+}
+
+func TestPruneOnchainSnapshots(t *testing.T) {
+	reg := loadTestRegistry(t)
+	out := fs.NewMemFS()
+	_, err := WriteOnchainSnapshots(out, map[uint64]*OnchainSnapshot{
+		11155420: {ChainID: 11155420},
+		999:      {ChainID: 999},
+	})
+	require.NoError(t, err)
+	require.NoError(t, out.WriteFile(paths.OnchainSnapshotsDir(".")+"/README.md", nil, 0o644))
+
+	removed, err := PruneOnchainSnapshots(out, reg)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{999}, removed)
+	require.Equal(t, []string{
+		paths.OnchainSnapshotFile(".", 11155420),
+		paths.OnchainSnapshotsDir(".") + "/README.md",
+	}, out.Files())
+
+	removed, err = PruneOnchainSnapshots(fs.NewMemFS(), reg)
+	require.NoError(t, err)
+	require.Empty(t, removed)
+}
+
+func TestPinnedL1Blocks(t *testing.T) {
+	reg := loadTestRegistry(t)
+	block := l1block.Ref{Number: 100, Hash: common.Hash{0x01}}
+	snapshots := map[uint64]*OnchainSnapshot{
+		11155420: {ChainID: 11155420, L1Block: block},
+		999:      {ChainID: 999, L1Block: block},
+	}
+
+	require.Equal(t, map[uint64]l1block.Ref{11155420: block}, PinnedL1Blocks(reg, snapshots, map[config.Superchain]l1block.Spec{
+		config.SepoliaSuperchain: "100",
+	}))
+	require.Empty(t, PinnedL1Blocks(reg, snapshots, map[config.Superchain]l1block.Spec{}))
+	require.Empty(t, PinnedL1Blocks(reg, snapshots, map[config.Superchain]l1block.Spec{
+		config.MainnetSuperchain: "100",
+	}))
+}
+
+func TestNewSnapshotCodegenSyncer(t *testing.T) {
+	chainCfgs := createTestChainConfigs(t)
+	chainIDs := slices.Sorted(maps.Keys(chainCfgs))
+	snapshots := make(map[uint64]*OnchainSnapshot)
+	l1Blocks := make(map[uint64]l1block.Ref)
+	for chainID, cfg := range chainCfgs {
+		l1Blocks[chainID] = l1block.Ref{Number: 100, Hash: common.Hash{0x01}}
+		snapshots[chainID] = &OnchainSnapshot{
+			ChainID: chainID,
+			L1Block: l1Blocks[chainID],
+			Config:  cfg,
+		}
+	}
+
+	// A registry without snapshots points to the command that writes them.
+	_, err := ReadOnchainSnapshots(loadTestRegistry(t), chainIDs, nil)
+	require.ErrorIs(t, err, iofs.ErrNotExist)
+	require.ErrorContains(t, err, "run refresh-onchain first")
+
+	// Snapshots are read from the registry, here from an overlay of it.
+	snapshotFS := fs.NewMemFS()
+	_, err = WriteOnchainSnapshots(snapshotFS, snapshots)
+	require.NoError(t, err)
+	reg, err := LoadRegistryOverlay(fs.NewDirFS("testdata"), []iofs.FS{snapshotFS}, false)
+	require.NoError(t, err)
+	readSnapshots, err := ReadOnchainSnapshots(reg, chainIDs, nil)
+	require.NoError(t, err)
+	require.Len(t, readSnapshots, len(chainIDs))
+
+	lgr := log.NewLogger(log.DiscardHandler())
+	fromSnapshots := fs.NewMemFS()
+	syncer, err := NewSnapshotCodegenSyncer(lgr, reg, readSnapshots, WithOutputFS(fromSnapshots))
+	require.NoError(t, err)
+	require.NoError(t, syncer.SyncAll())
+
+	fetched := fs.NewMemFS()
+	syncer, err = NewCodegenSyncer(lgr, reg, chainCfgs, WithOutputFS(fetched), WithL1Blocks(l1Blocks))
+	require.NoError(t, err)
+	require.NoError(t, syncer.SyncAll())
+
+	diff, err := DiffCodegenFiles(fetched, fromSnapshots)
+	require.NoError(t, err)
+	require.False(t, diff.Stale(), "stale files: %v", diff.Files)
+
+	var recorded map[string]l1block.Ref
+	require.NoError(t, paths.ReadJSONFileFS(fromSnapshots, paths.L1BlocksFile("."), &recorded))
+	require.Len(t, recorded, len(chainIDs))
+	for _, chainID := range chainIDs {
+		require.Equal(t, l1Blocks[chainID], recorded[strconv.FormatUint(chainID, 10)])
+	}
+}
@@ -2,7 +2,7 @@ package manage
 
 import (
 	"fmt"
-	"os"
+	iofs "io/fs"
 
 	"github.com/ethereum-optimism/optimism/op-fetcher/pkg/fetcher/fetch/script"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
@@ -15,22 +15,22 @@ var requiredSuperchains = []config.Superchain{
 	config.SepoliaSuperchain,
 }
 
-func ValidateRequiredSuperchains(wd string) error {
+func ValidateRequiredSuperchains(fsys iofs.FS) error {
 	for _, superchain := range requiredSuperchains {
-		configPath := paths.SuperchainConfig(wd, superchain)
-		if _, err := os.Stat(configPath); err != nil {
+		configPath := paths.SuperchainConfig(".", superchain)
+		if _, err := iofs.Stat(fsys, configPath); err != nil {
 			return fmt.Errorf("required superchain %s is missing its superchain config: %w", superchain, err)
 		}
 	}
 	return nil
 }
 
-func PruneRemovedChains(lgr log.Logger, wd string) error {
-	if err := ValidateRequiredSuperchains(wd); err != nil {
+func PruneRemovedChains(lgr log.Logger, reg *Registry, opts ...CodegenSyncerOption) error {
+	if err := ValidateRequiredSuperchains(reg.FS()); err != nil {
 		return err
 	}
 
-	syncer, err := NewCodegenSyncer(lgr, wd, make(map[uint64]script.ChainConfig))
+	syncer, err := NewCodegenSyncer(lgr, reg, make(map[uint64]script.ChainConfig), opts...)
 	if err != nil {
 		return fmt.Errorf("error creating codegen syncer: %w", err)
 	}
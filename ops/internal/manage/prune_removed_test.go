@@ -24,10 +24,10 @@ func writeRequiredSuperchainConfigs(t *testing.T, wd string) {
 func TestValidateRequiredSuperchains(t *testing.T) {
 	wd := t.TempDir()
 	writeRequiredSuperchainConfigs(t, wd)
-	require.NoError(t, ValidateRequiredSuperchains(wd))
+	require.NoError(t, ValidateRequiredSuperchains(os.DirFS(wd)))
 
 	require.NoError(t, os.Remove(paths.SuperchainConfig(wd, config.MainnetSuperchain)))
-	err := ValidateRequiredSuperchains(wd)
+	err := ValidateRequiredSuperchains(os.DirFS(wd))
 	require.ErrorContains(t, err, "required superchain mainnet")
 }
 
@@ -47,7 +47,9 @@ func TestPruneRemovedChains(t *testing.T) {
 	require.NoError(t, os.WriteFile(paths.AddressesFile(wd), addressesJSON, 0o644))
 
 	lgr := log.NewLogger(log.DiscardHandler())
-	require.NoError(t, PruneRemovedChains(lgr, wd))
+	reg, err := LoadRegistry(wd)
+	require.NoError(t, err)
+	require.NoError(t, PruneRemovedChains(lgr, reg))
 
 	var gotChainList []config.ChainListEntry
 	data, err := os.ReadFile(paths.ChainListJsonFile(wd))
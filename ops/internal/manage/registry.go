@@ -0,0 +1,375 @@
+package manage
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+)
+
+type fileHash [sha256.Size]byte
+
+// parseCache holds parsed files keyed by the hash of their contents, so that
+// unchanged files are not parsed again when a Registry is reloaded.
+type parseCache[T any] map[fileHash]*T
+
+// get returns the parsed contents of data from c, or parses them if they are not
+// cached. The result is also stored in next, which replaces c once a reload
+// finishes, so that entries of deleted or changed files are dropped.
+func (c parseCache[T]) get(next parseCache[T], data []byte, parse func([]byte, *T) error) (*T, error) {
+	hash := fileHash(sha256.Sum256(data))
+	if v, ok := c[hash]; ok {
+		next[hash] = v
+		return v, nil
+	}
+	v := new(T)
+	if err := parse(data, v); err != nil {
+		return nil, err
+	}
+	next[hash] = v
+	return v, nil
+}
+
+// Registry is an index of the chain configs, superchain definitions,
+// addresses.json and chainList.json of a registry. It is meant to be loaded
+// once per command and passed to everything that needs the registry's
+// contents. Commands that change the registry call Reload, which only parses
+// files whose contents changed.
+//
+// Configs are shared between lookups and reloads, so callers must not modify
+// them. A Registry is not safe for concurrent use.
+type Registry struct {
+	fsys   iofs.FS
+	strict bool
+
+	chainCache      parseCache[config.Chain]
+	superchainCache parseCache[config.SuperchainDefinition]
+	addressesCache  parseCache[config.AddressesJSON]
+	chainListCache  parseCache[[]config.ChainListEntry]
+
+	superchains       map[config.Superchain]*config.SuperchainDefinition
+	chains            []DiskChainConfig
+	chainsByID        map[uint64]int
+	chainsByShortName map[config.Superchain]map[string]int
+	addresses         config.AddressesJSON
+	chainList         []config.ChainListEntry
+}
+
+// LoadRegistry loads the registry at wd. addresses.json and chainList.json are
+// optional, and are empty if they do not exist.
+func LoadRegistry(wd string) (*Registry, error) {
+	return LoadRegistryFS(fs.NewDirFS(wd), false)
+}
+
+// LoadRegistryStrict is like LoadRegistry, but fails if any chain config or
+// superchain config contains keys that its config struct has no field for.
+func LoadRegistryStrict(wd string) (*Registry, error) {
+	return LoadRegistryFS(fs.NewDirFS(wd), true)
+}
+
+// LoadRegistryFS loads the registry at the root of fsys, such as one opened by
+// paths.OpenSource. If strict is set, it fails if any chain config or
+// superchain config contains keys that its config struct has no field for.
+func LoadRegistryFS(fsys iofs.FS, strict bool) (*Registry, error) {
+	r := &Registry{
+		fsys:   fsys,
+		strict: strict,
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// LoadRegistryOverlay loads the registry at the root of base with overlays,
+// such as ones opened by paths.OpenOverlays, merged on top of it in order. The
+// chain configs of an overlay must not share a chain ID or short name with the
+// chains of base or earlier overlays. Any other file of an overlay, such as a
+// superchain config or generated file, replaces the file of the same name
+// below it.
+func LoadRegistryOverlay(base iofs.FS, overlays []iofs.FS, strict bool) (*Registry, error) {
+	if len(overlays) == 0 {
+		return LoadRegistryFS(base, strict)
+	}
+	if err := ValidateOverlays(base, overlays); err != nil {
+		return nil, err
+	}
+	return LoadRegistryFS(fs.NewOverlayFS(base, overlays...), strict)
+}
+
+// ValidateOverlays checks that the chain configs of every overlay are unique
+// in the registry at base merged with the overlays before it, as
+// ValidateUniqueness does for staged chains.
+func ValidateOverlays(base iofs.FS, overlays []iofs.FS) error {
+	chains, err := CollectChainConfigsFS(base, paths.SuperchainConfigsDir("."))
+	if err != nil {
+		return fmt.Errorf("error collecting chain configs: %w", err)
+	}
+
+	for _, overlay := range overlays {
+		cfgs, err := CollectChainConfigsFS(overlay, paths.SuperchainConfigsDir("."))
+		if err != nil {
+			return fmt.Errorf("error collecting overlay chain configs: %w", err)
+		}
+		for _, cfg := range cfgs {
+			staged := &config.StagedChain{
+				Chain:      *cfg.Config,
+				ShortName:  cfg.ShortName,
+				Superchain: cfg.Superchain,
+			}
+			if err := ValidateUniqueness(staged, chains); err != nil {
+				return fmt.Errorf("overlay chain config %s conflicts with the registry: %w", diskPath(overlay, cfg.Filepath), err)
+			}
+		}
+		chains = append(chains, cfgs...)
+	}
+	return nil
+}
+
+// Reload re-reads every file of the registry, and parses the ones whose contents
+// changed since the last load. If it fails, the registry is left unchanged.
+func (r *Registry) Reload() error {
+	next := &Registry{
+		fsys:              r.fsys,
+		strict:            r.strict,
+		chainCache:        make(parseCache[config.Chain]),
+		superchainCache:   make(parseCache[config.SuperchainDefinition]),
+		addressesCache:    make(parseCache[config.AddressesJSON]),
+		chainListCache:    make(parseCache[[]config.ChainListEntry]),
+		superchains:       make(map[config.Superchain]*config.SuperchainDefinition),
+		chainsByID:        make(map[uint64]int),
+		chainsByShortName: make(map[config.Superchain]map[string]int),
+	}
+
+	if err := next.loadSuperchains(r.superchainCache); err != nil {
+		return err
+	}
+	if err := next.loadChains(r.chainCache); err != nil {
+		return err
+	}
+
+	addresses, err := readCachedFile(r.fsys, r.addressesCache, next.addressesCache, paths.AddressesFile("."), func(data []byte, out *config.AddressesJSON) error {
+		return json.Unmarshal(data, out)
+	})
+	if err != nil {
+		return fmt.Errorf("error reading addresses file: %w", err)
+	}
+	if addresses != nil {
+		next.addresses = *addresses
+	}
+
+	chainList, err := readCachedFile(r.fsys, r.chainListCache, next.chainListCache, paths.ChainListJsonFile("."), func(data []byte, out *[]config.ChainListEntry) error {
+		return json.Unmarshal(data, out)
+	})
+	if err != nil {
+		return fmt.Errorf("error reading chainList file: %w", err)
+	}
+	if chainList != nil {
+		next.chainList = *chainList
+	}
+
+	*r = *next
+	return nil
+}
+
+// readCachedFile reads and parses the file name in fsys through cache. It returns
+// nil if the file does not exist.
+func readCachedFile[T any](fsys iofs.FS, cache, next parseCache[T], name string, parse func([]byte, *T) error) (*T, error) {
+	data, err := iofs.ReadFile(fsys, name)
+	if errors.Is(err, iofs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cache.get(next, data, parse)
+}
+
+func (r *Registry) loadSuperchains(cache parseCache[config.SuperchainDefinition]) error {
+	superchains, err := paths.SuperchainsFS(r.fsys)
+	if err != nil {
+		return fmt.Errorf("error getting superchains: %w", err)
+	}
+
+	for _, superchain := range superchains {
+		name := paths.SuperchainConfig(".", superchain)
+		def, err := readCachedFile(r.fsys, cache, r.superchainCache, name, func(data []byte, out *config.SuperchainDefinition) error {
+			if r.strict {
+				return paths.UnmarshalTOMLStrict(name, data, out)
+			}
+			return toml.Unmarshal(data, out)
+		})
+		if err != nil {
+			return fmt.Errorf("error reading superchain config for %s: %w", superchain, err)
+		}
+		if def != nil {
+			r.superchains[superchain] = def
+		}
+	}
+	return nil
+}
+
+func (r *Registry) loadChains(cache parseCache[config.Chain]) error {
+	var files []string
+	err := iofs.WalkDir(r.fsys, paths.SuperchainConfigsDir("."), func(fp string, d iofs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && isChainConfigFile(fp) {
+			files = append(files, fp)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to walk directory: %w", err)
+	}
+
+	contents := make(map[string][]byte, len(files))
+	hashes := make(map[string]fileHash, len(files))
+	var changed []string
+	for _, file := range files {
+		data, err := iofs.ReadFile(r.fsys, file)
+		if err != nil {
+			return fmt.Errorf("failed to read file %s: %w", file, err)
+		}
+		contents[file] = data
+		hashes[file] = sha256.Sum256(data)
+		if _, ok := cache[hashes[file]]; !ok {
+			changed = append(changed, file)
+		}
+	}
+
+	// Chain configs are decoded in parallel rather than through cache.get, since
+	// a full load decodes every one of them.
+	decoded, err := decodeChainConfigs(changed, func(file string) ([]byte, error) {
+		return contents[file], nil
+	}, r.strict)
+	if err != nil {
+		return err
+	}
+	decodedByFile := make(map[string]*config.Chain, len(decoded))
+	for _, cfg := range decoded {
+		decodedByFile[cfg.Filepath] = cfg.Config
+	}
+
+	for _, file := range files {
+		chain, ok := cache[hashes[file]]
+		if !ok {
+			chain = decodedByFile[file]
+		}
+		r.chainCache[hashes[file]] = chain
+		r.chains = append(r.chains, DiskChainConfig{
+			ShortName:  strings.TrimSuffix(path.Base(file), ".toml"),
+			Filepath:   r.diskPath(file),
+			Superchain: path.Base(path.Dir(file)),
+			Config:     chain,
+		})
+	}
+	slices.SortStableFunc(r.chains, func(a, b DiskChainConfig) int {
+		return cmp.Compare(a.Config.Name, b.Config.Name)
+	})
+
+	for i, cfg := range r.chains {
+		if j, ok := r.chainsByID[cfg.Config.ChainID]; ok {
+			return fmt.Errorf("duplicate chain ID %d in %s and %s", cfg.Config.ChainID, r.chains[j].Filepath, cfg.Filepath)
+		}
+		r.chainsByID[cfg.Config.ChainID] = i
+
+		if r.chainsByShortName[cfg.Superchain] == nil {
+			r.chainsByShortName[cfg.Superchain] = make(map[string]int)
+		}
+		r.chainsByShortName[cfg.Superchain][cfg.ShortName] = i
+	}
+	return nil
+}
+
+// FS returns the file system the registry is read from.
+func (r *Registry) FS() iofs.FS {
+	return r.fsys
+}
+
+// diskPath returns the path on disk of name if the registry is on disk, and
+// name otherwise.
+func (r *Registry) diskPath(name string) string {
+	return diskPath(r.fsys, name)
+}
+
+func diskPath(fsys iofs.FS, name string) string {
+	if dir, ok := fsys.(interface{ Path(string) string }); ok {
+		return dir.Path(name)
+	}
+	return name
+}
+
+// Superchains returns the names of the superchains with a superchain config,
+// sorted.
+func (r *Registry) Superchains() []config.Superchain {
+	return slices.Sorted(maps.Keys(r.superchains))
+}
+
+// SuperchainDefinition returns the superchain config of superchain.
+func (r *Registry) SuperchainDefinition(superchain config.Superchain) (*config.SuperchainDefinition, bool) {
+	def, ok := r.superchains[superchain]
+	return def, ok
+}
+
+// Chains returns every chain config, sorted by name like CollectChainConfigs.
+func (r *Registry) Chains() []DiskChainConfig {
+	return slices.Clone(r.chains)
+}
+
+// ChainsBySuperchain returns the chain configs of superchain, sorted by name.
+func (r *Registry) ChainsBySuperchain(superchain config.Superchain) []DiskChainConfig {
+	var out []DiskChainConfig
+	for _, cfg := range r.chains {
+		if cfg.Superchain == superchain {
+			out = append(out, cfg)
+		}
+	}
+	return out
+}
+
+// ChainByID returns the config of the chain with the given chain ID.
+func (r *Registry) ChainByID(chainID uint64) (DiskChainConfig, bool) {
+	i, ok := r.chainsByID[chainID]
+	if !ok {
+		return DiskChainConfig{}, false
+	}
+	return r.chains[i], true
+}
+
+// ChainByShortName returns the config of the chain with the given short name in
+// superchain.
+func (r *Registry) ChainByShortName(superchain config.Superchain, shortName string) (DiskChainConfig, bool) {
+	i, ok := r.chainsByShortName[superchain][shortName]
+	if !ok {
+		return DiskChainConfig{}, false
+	}
+	return r.chains[i], true
+}
+
+// Addresses returns a copy of addresses.json. The entries themselves are
+// shared.
+func (r *Registry) Addresses() config.AddressesJSON {
+	out := maps.Clone(r.addresses)
+	if out == nil {
+		out = make(config.AddressesJSON)
+	}
+	return out
+}
+
+// ChainList returns a copy of chainList.json.
+func (r *Registry) ChainList() []config.ChainListEntry {
+	return slices.Clone(r.chainList)
+}
@@ -0,0 +1,403 @@
+package manage
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RegistryDiff is the semantic difference between two registry snapshots.
+// Chains are matched by chain ID.
+type RegistryDiff struct {
+	AddedChains   []DiffChain         `json:"addedChains"`
+	RemovedChains []DiffChain         `json:"removedChains"`
+	Hardforks     []HardforkChange    `json:"hardforks"`
+	Addresses     []AddressChange     `json:"addresses"`
+	FaultProofs   []FaultProofChange  `json:"faultProofs"`
+	GenesisHashes []GenesisHashChange `json:"genesisHashes"`
+}
+
+type DiffChain struct {
+	Superchain config.Superchain `json:"superchain"`
+	ShortName  string            `json:"shortName"`
+	Name       string            `json:"name"`
+	ChainID    uint64            `json:"chainId"`
+}
+
+func (c DiffChain) String() string {
+	return fmt.Sprintf("%s/%s (%d)", c.Superchain, c.ShortName, c.ChainID)
+}
+
+// HardforkChange is a changed activation time in a superchain definition, if
+// Chain is nil, or in a chain config. Changes to a superchain definition list
+// the chains whose configs changed along with it, as apply_hardforks would
+// have changed them; those chain changes are not reported separately.
+type HardforkChange struct {
+	Superchain  config.Superchain `json:"superchain"`
+	Chain       *DiffChain        `json:"chain,omitempty"`
+	Hardfork    string            `json:"hardfork"`
+	Key         string            `json:"key"`
+	Old         *uint64           `json:"old"`
+	New         *uint64           `json:"new"`
+	InheritedBy []DiffChain       `json:"inheritedBy,omitempty"`
+}
+
+// AddressChange is a changed contract address or role of a chain in
+// addresses.json. Old is nil for added entries, and New for removed ones.
+type AddressChange struct {
+	Chain DiffChain                  `json:"chain"`
+	Name  string                     `json:"name"`
+	Role  bool                       `json:"role"`
+	Old   *config.ChecksummedAddress `json:"old"`
+	New   *config.ChecksummedAddress `json:"new"`
+}
+
+// FaultProofChange is a changed fault proof status of a chain in chainList.json.
+type FaultProofChange struct {
+	Chain DiffChain `json:"chain"`
+	Old   string    `json:"old"`
+	New   string    `json:"new"`
+}
+
+// GenesisHashChange is a changed L1 or L2 genesis block hash in a chain config.
+type GenesisHashChange struct {
+	Chain DiffChain   `json:"chain"`
+	Layer string      `json:"layer"`
+	Old   common.Hash `json:"old"`
+	New   common.Hash `json:"new"`
+}
+
+// DiffRegistries computes the semantic difference from base to head. Chains
+// that were added or removed are only reported as such, not as changes to
+// each of their fields.
+func DiffRegistries(base, head *Registry) *RegistryDiff {
+	diff := new(RegistryDiff)
+
+	for _, cfg := range head.Chains() {
+		if _, ok := base.ChainByID(cfg.Config.ChainID); !ok {
+			diff.AddedChains = append(diff.AddedChains, newDiffChain(cfg))
+		}
+	}
+	for _, cfg := range base.Chains() {
+		if _, ok := head.ChainByID(cfg.Config.ChainID); !ok {
+			diff.RemovedChains = append(diff.RemovedChains, newDiffChain(cfg))
+		}
+	}
+
+	diff.Hardforks = diffHardforks(base, head)
+
+	baseAddrs := base.Addresses()
+	headAddrs := head.Addresses()
+	baseChainList := chainListByID(base.ChainList())
+	headChainList := chainListByID(head.ChainList())
+	for _, headCfg := range head.Chains() {
+		baseCfg, ok := base.ChainByID(headCfg.Config.ChainID)
+		if !ok {
+			continue
+		}
+		chain := newDiffChain(headCfg)
+
+		chainIDStr := strconv.FormatUint(chain.ChainID, 10)
+		diff.Addresses = append(diff.Addresses, diffAddresses(chain, baseAddrs[chainIDStr], headAddrs[chainIDStr])...)
+
+		baseStatus := baseChainList[chain.ChainID].FaultProofs.Status
+		headStatus := headChainList[chain.ChainID].FaultProofs.Status
+		if baseStatus != headStatus {
+			diff.FaultProofs = append(diff.FaultProofs, FaultProofChange{
+				Chain: chain,
+				Old:   baseStatus,
+				New:   headStatus,
+			})
+		}
+
+		for _, ref := range []struct {
+			layer      string
+			base, head common.Hash
+		}{
+			{"l1", baseCfg.Config.Genesis.L1.Hash, headCfg.Config.Genesis.L1.Hash},
+			{"l2", baseCfg.Config.Genesis.L2.Hash, headCfg.Config.Genesis.L2.Hash},
+		} {
+			if ref.base != ref.head {
+				diff.GenesisHashes = append(diff.GenesisHashes, GenesisHashChange{
+					Chain: chain,
+					Layer: ref.layer,
+					Old:   ref.base,
+					New:   ref.head,
+				})
+			}
+		}
+	}
+
+	return diff
+}
+
+// Empty returns whether the snapshots are semantically identical.
+func (d *RegistryDiff) Empty() bool {
+	return len(d.AddedChains) == 0 && len(d.RemovedChains) == 0 && len(d.Hardforks) == 0 &&
+		len(d.Addresses) == 0 && len(d.FaultProofs) == 0 && len(d.GenesisHashes) == 0
+}
+
+func newDiffChain(cfg DiskChainConfig) DiffChain {
+	return DiffChain{
+		Superchain: cfg.Superchain,
+		ShortName:  cfg.ShortName,
+		Name:       cfg.Config.Name,
+		ChainID:    cfg.Config.ChainID,
+	}
+}
+
+func diffHardforks(base, head *Registry) []HardforkChange {
+	var changes []HardforkChange
+	for _, superchain := range head.Superchains() {
+		baseDef, baseOk := base.SuperchainDefinition(superchain)
+		headDef, _ := head.SuperchainDefinition(superchain)
+		var baseForks config.Hardforks
+		if baseOk {
+			baseForks = baseDef.Hardforks
+		}
+
+		// inherited holds the chains whose change of a fork is explained by the
+		// superchain's change of it, keyed by fork key and chain ID.
+		inherited := make(map[string]map[uint64]bool)
+		for _, fork := range config.AllHardforks {
+			oldTime := fork.Time(&baseForks).U64Ptr()
+			newTime := fork.Time(&headDef.Hardforks).U64Ptr()
+			if equalTimes(oldTime, newTime) {
+				continue
+			}
+
+			change := HardforkChange{
+				Superchain: superchain,
+				Hardfork:   fork.Name,
+				Key:        fork.TOMLKey,
+				Old:        oldTime,
+				New:        newTime,
+			}
+			inherited[fork.TOMLKey] = make(map[uint64]bool)
+			for _, headCfg := range head.ChainsBySuperchain(superchain) {
+				baseCfg, ok := base.ChainByID(headCfg.Config.ChainID)
+				if !ok {
+					continue
+				}
+				chainOld := fork.Time(&baseCfg.Config.Hardforks).U64Ptr()
+				chainNew := fork.Time(&headCfg.Config.Hardforks).U64Ptr()
+				if equalTimes(chainOld, chainNew) || !equalTimes(chainNew, inheritedTime(fork, headCfg.Config, newTime)) {
+					continue
+				}
+				change.InheritedBy = append(change.InheritedBy, newDiffChain(headCfg))
+				inherited[fork.TOMLKey][headCfg.Config.ChainID] = true
+			}
+			changes = append(changes, change)
+		}
+
+		for _, headCfg := range head.ChainsBySuperchain(superchain) {
+			baseCfg, ok := base.ChainByID(headCfg.Config.ChainID)
+			if !ok {
+				continue
+			}
+			chain := newDiffChain(headCfg)
+			for _, fork := range config.AllHardforks {
+				oldTime := fork.Time(&baseCfg.Config.Hardforks).U64Ptr()
+				newTime := fork.Time(&headCfg.Config.Hardforks).U64Ptr()
+				if equalTimes(oldTime, newTime) || inherited[fork.TOMLKey][chain.ChainID] {
+					continue
+				}
+				changes = append(changes, HardforkChange{
+					Superchain: superchain,
+					Chain:      &chain,
+					Hardfork:   fork.Name,
+					Key:        fork.TOMLKey,
+					Old:        oldTime,
+					New:        newTime,
+				})
+			}
+		}
+	}
+	return changes
+}
+
+// inheritedTime returns the activation time cfg gets from a superchain-wide
+// activation of fork at superchainTime, following config.CopyHardforks. It
+// returns nil if cfg does not inherit the activation.
+func inheritedTime(fork config.Hardfork, cfg *config.Chain, superchainTime *uint64) *uint64 {
+	if cfg.SuperchainTime == nil || superchainTime == nil || *superchainTime < *cfg.SuperchainTime {
+		return nil
+	}
+	if *superchainTime > cfg.Genesis.L2Time {
+		return superchainTime
+	}
+	if fork.Corrective {
+		return nil
+	}
+	zero := uint64(0)
+	return &zero
+}
+
+func equalTimes(a, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func chainListByID(chainList []config.ChainListEntry) map[uint64]config.ChainListEntry {
+	out := make(map[uint64]config.ChainListEntry, len(chainList))
+	for _, entry := range chainList {
+		out[entry.ChainID] = entry
+	}
+	return out
+}
+
+func diffAddresses(chain DiffChain, base, head *config.AddressesWithRoles) []AddressChange {
+	if base == nil {
+		base = new(config.AddressesWithRoles)
+	}
+	if head == nil {
+		head = new(config.AddressesWithRoles)
+	}
+
+	var changes []AddressChange
+	diffFields := func(base, head any, role bool) {
+		baseVal := reflect.ValueOf(base)
+		headVal := reflect.ValueOf(head)
+		for i := range baseVal.NumField() {
+			oldAddr := baseVal.Field(i).Interface().(*config.ChecksummedAddress)
+			newAddr := headVal.Field(i).Interface().(*config.ChecksummedAddress)
+			if equalAddresses(oldAddr, newAddr) {
+				continue
+			}
+			changes = append(changes, AddressChange{
+				Chain: chain,
+				Name:  baseVal.Type().Field(i).Name,
+				Role:  role,
+				Old:   oldAddr,
+				New:   newAddr,
+			})
+		}
+	}
+	diffFields(base.Roles, head.Roles, true)
+	diffFields(base.Addresses, head.Addresses, false)
+	return changes
+}
+
+func equalAddresses(a, b *config.ChecksummedAddress) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (d *RegistryDiff) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(d); err != nil {
+		return fmt.Errorf("failed to encode registry diff: %w", err)
+	}
+	return nil
+}
+
+// WriteMarkdown writes a section per kind of change, omitting kinds without
+// changes. Address changes are grouped by chain.
+func (d *RegistryDiff) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("# Registry Diff\n")
+	if d.Empty() {
+		b.WriteString("\nNo semantic changes.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	writeChains := func(title string, chains []DiffChain) {
+		if len(chains) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n| Chain | Name | Chain ID |\n|---|---|---|\n", title)
+		for _, chain := range chains {
+			fmt.Fprintf(&b, "| %s/%s | %s | %d |\n", chain.Superchain, chain.ShortName, chain.Name, chain.ChainID)
+		}
+	}
+	writeChains("Added Chains", d.AddedChains)
+	writeChains("Removed Chains", d.RemovedChains)
+
+	if len(d.Hardforks) > 0 {
+		b.WriteString("\n## Hardforks\n\n| Scope | Hardfork | Old | New | Inherited By |\n|---|---|---|---|---|\n")
+		for _, change := range d.Hardforks {
+			scope := fmt.Sprintf("**%s** (superchain)", change.Superchain)
+			if change.Chain != nil {
+				scope = change.Chain.String()
+			}
+			inheritedBy := make([]string, len(change.InheritedBy))
+			for i, chain := range change.InheritedBy {
+				inheritedBy[i] = chain.String()
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", scope, change.Hardfork,
+				formatDiffTime(change.Old), formatDiffTime(change.New), strings.Join(inheritedBy, ", "))
+		}
+	}
+
+	if len(d.Addresses) > 0 {
+		b.WriteString("\n## Addresses\n")
+		byChain := slices.Clone(d.Addresses)
+		slices.SortStableFunc(byChain, func(a, b AddressChange) int {
+			return cmp.Compare(a.Chain.ChainID, b.Chain.ChainID)
+		})
+		var current uint64
+		for i, change := range byChain {
+			if i == 0 || change.Chain.ChainID != current {
+				current = change.Chain.ChainID
+				fmt.Fprintf(&b, "\n### %s\n\n| Kind | Name | Old | New |\n|---|---|---|---|\n", change.Chain)
+			}
+			kind := "contract"
+			if change.Role {
+				kind = "role"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", kind, change.Name, formatDiffAddress(change.Old), formatDiffAddress(change.New))
+		}
+	}
+
+	if len(d.FaultProofs) > 0 {
+		b.WriteString("\n## Fault Proofs\n\n| Chain | Old | New |\n|---|---|---|\n")
+		for _, change := range d.FaultProofs {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", change.Chain, formatDiffString(change.Old), formatDiffString(change.New))
+		}
+	}
+
+	if len(d.GenesisHashes) > 0 {
+		b.WriteString("\n## Genesis Hashes\n\n| Chain | Layer | Old | New |\n|---|---|---|---|\n")
+		for _, change := range d.GenesisHashes {
+			fmt.Fprintf(&b, "| %s | %s | `%s` | `%s` |\n", change.Chain, strings.ToUpper(change.Layer), change.Old, change.New)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func formatDiffTime(t *uint64) string {
+	if t == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s (`%d`)", formatActivationDate(*t), *t)
+}
+
+func formatDiffAddress(addr *config.ChecksummedAddress) string {
+	if addr == nil {
+		return "-"
+	}
+	return fmt.Sprintf("`%s`", addr)
+}
+
+func formatDiffString(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
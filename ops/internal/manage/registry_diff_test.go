@@ -0,0 +1,191 @@
+package manage
+
+import (
+	"bytes"
+	"encoding/json"
+	iofs "io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffRegistries(t *testing.T) {
+	base, err := LoadRegistryFS(os.DirFS("testdata"), false)
+	require.NoError(t, err)
+
+	headFS := copyToMemFS(t, os.DirFS("testdata"))
+	editFile := func(name string, old string, new string) {
+		data, err := iofs.ReadFile(headFS, name)
+		require.NoError(t, err)
+		require.Equal(t, 1, strings.Count(string(data), old))
+		require.NoError(t, headFS.WriteFile(name, []byte(strings.Replace(string(data), old, new, 1)), 0o644))
+	}
+
+	// Isthmus is scheduled for the superchain and applied to op, but not to
+	// testchain, which changes granite on its own.
+	editFile(paths.SuperchainConfig(".", config.SepoliaSuperchain),
+		"holocene_time = 1732633200 # Tue Nov 26 15:00:00 UTC 2024\n",
+		"holocene_time = 1732633200 # Tue Nov 26 15:00:00 UTC 2024\nisthmus_time = 1744905600\n")
+	editFile(paths.ChainConfig(".", config.SepoliaSuperchain, "op"),
+		"  holocene_time = 1732633200 # Tue 26 Nov 2024 15:00:00 UTC\n",
+		"  holocene_time = 1732633200 # Tue 26 Nov 2024 15:00:00 UTC\n  isthmus_time = 1744905600\n")
+	editFile(paths.ChainConfig(".", config.SepoliaSuperchain, "testchain"),
+		"granite_time = 0 #",
+		"granite_time = 5 #")
+	editFile(paths.ChainConfig(".", config.SepoliaSuperchain, "op"),
+		"0x102de6ffb001480cc9b8b548fd05c34cd4f46ae4aa91759393db90ea0409887d",
+		"0x0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, headFS.WriteFile(paths.ChainConfig(".", config.SepoliaSuperchain, "newchain"),
+		[]byte("name = \"New Chain\"\nchain_id = 99\n"), 0o644))
+
+	newGuardian := config.ChecksummedAddress(common.HexToAddress("0x1111111111111111111111111111111111111111"))
+	addresses := loadTestAddressesJSON(t)
+	oldGuardian := addresses["11155420"].Guardian
+	oldAddressManager := addresses["11155420"].AddressManager
+	addresses["11155420"].Guardian = &newGuardian
+	addresses["11155420"].AddressManager = nil
+	writeJSON(t, headFS, paths.AddressesFile("."), addresses)
+
+	chainList := loadTestChainList(t)
+	chainList[0].FaultProofs = config.FaultProofs{Status: "permissioned"}
+	writeJSON(t, headFS, paths.ChainListJsonFile("."), chainList)
+
+	head, err := LoadRegistryFS(headFS, false)
+	require.NoError(t, err)
+
+	op := DiffChain{Superchain: config.SepoliaSuperchain, ShortName: "op", Name: "OP Sepolia Testnet", ChainID: 11155420}
+	testchain := DiffChain{Superchain: config.SepoliaSuperchain, ShortName: "testchain", Name: "TestChain", ChainID: 1952805748}
+	newchain := DiffChain{Superchain: config.SepoliaSuperchain, ShortName: "newchain", Name: "New Chain", ChainID: 99}
+	isthmusTime := uint64(1744905600)
+	oldGranite := uint64(0)
+	newGranite := uint64(5)
+
+	diff := DiffRegistries(base, head)
+	require.Equal(t, []DiffChain{newchain}, diff.AddedChains)
+	require.Empty(t, diff.RemovedChains)
+	require.Equal(t, []HardforkChange{
+		{
+			Superchain:  config.SepoliaSuperchain,
+			Hardfork:    "Isthmus",
+			Key:         "isthmus_time",
+			New:         &isthmusTime,
+			InheritedBy: []DiffChain{op},
+		},
+		{
+			Superchain: config.SepoliaSuperchain,
+			Chain:      &testchain,
+			Hardfork:   "Granite",
+			Key:        "granite_time",
+			Old:        &oldGranite,
+			New:        &newGranite,
+		},
+	}, diff.Hardforks)
+	require.Equal(t, []AddressChange{
+		{Chain: op, Name: "Guardian", Role: true, Old: oldGuardian, New: &newGuardian},
+		{Chain: op, Name: "AddressManager", Old: oldAddressManager},
+	}, diff.Addresses)
+	require.Equal(t, []FaultProofChange{{Chain: op, New: "permissioned"}}, diff.FaultProofs)
+	require.Equal(t, []GenesisHashChange{{
+		Chain: op,
+		Layer: "l2",
+		Old:   common.HexToHash("0x102de6ffb001480cc9b8b548fd05c34cd4f46ae4aa91759393db90ea0409887d"),
+		New:   common.HexToHash("0x01"),
+	}}, diff.GenesisHashes)
+
+	var md bytes.Buffer
+	require.NoError(t, diff.WriteMarkdown(&md))
+	require.Contains(t, md.String(), "| sepolia/newchain | New Chain | 99 |")
+	require.Contains(t, md.String(), "| **sepolia** (superchain) | Isthmus | - | 2025-04-17 16:00:00 (`1744905600`) | sepolia/op (11155420) |")
+	require.Contains(t, md.String(), "| sepolia/testchain (1952805748) | Granite | genesis (`0`) | 1970-01-01 00:00:05 (`5`) |  |")
+	require.Contains(t, md.String(), "### sepolia/op (11155420)")
+	require.Contains(t, md.String(), "| role | Guardian | `"+oldGuardian.String()+"` | `0x1111111111111111111111111111111111111111` |")
+	require.Contains(t, md.String(), "| contract | AddressManager | `"+oldAddressManager.String()+"` | - |")
+	require.Contains(t, md.String(), "| sepolia/op (11155420) | - | permissioned |")
+
+	var decoded RegistryDiff
+	var js bytes.Buffer
+	require.NoError(t, diff.WriteJSON(&js))
+	require.NoError(t, json.Unmarshal(js.Bytes(), &decoded))
+	require.Equal(t, *diff, decoded)
+
+	reverse := DiffRegistries(head, base)
+	require.Empty(t, reverse.AddedChains)
+	require.Equal(t, []DiffChain{newchain}, reverse.RemovedChains)
+
+	same := DiffRegistries(base, base)
+	require.True(t, same.Empty())
+	md.Reset()
+	require.NoError(t, same.WriteMarkdown(&md))
+	require.Equal(t, "# Registry Diff\n\nNo semantic changes.\n", md.String())
+}
+
+func TestInheritedTime(t *testing.T) {
+	at := func(t uint64) *uint64 { return &t }
+	forkByKey := func(key string) config.Hardfork {
+		for _, fork := range config.AllHardforks {
+			if fork.TOMLKey == key {
+				return fork
+			}
+		}
+		t.Fatalf("no hardfork %s", key)
+		return config.Hardfork{}
+	}
+	isthmus := forkByKey("isthmus_time")
+	blobSchedule := forkByKey("pectra_blob_schedule_time")
+
+	cfg := &config.Chain{SuperchainTime: at(10)}
+	cfg.Genesis.L2Time = 20
+
+	tests := []struct {
+		name           string
+		fork           config.Hardfork
+		superchainTime *uint64
+		expected       *uint64
+	}{
+		{"unscheduled", isthmus, nil, nil},
+		{"before superchain time", isthmus, at(5), nil},
+		{"before genesis", isthmus, at(15), at(0)},
+		{"at genesis", isthmus, at(20), at(0)},
+		{"after genesis", isthmus, at(25), at(25)},
+		{"corrective before genesis", blobSchedule, at(15), nil},
+		{"corrective at genesis", blobSchedule, at(20), nil},
+		{"corrective after genesis", blobSchedule, at(25), at(25)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, inheritedTime(tt.fork, cfg, tt.superchainTime))
+		})
+	}
+}
+
+func copyToMemFS(t *testing.T, src iofs.FS) *fs.MemFS {
+	t.Helper()
+
+	out := fs.NewMemFS()
+	err := iofs.WalkDir(src, ".", func(name string, d iofs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := iofs.ReadFile(src, name)
+		if err != nil {
+			return err
+		}
+		return out.WriteFile(name, data, 0o644)
+	})
+	require.NoError(t, err)
+	return out
+}
+
+func writeJSON(t *testing.T, fsys fs.WriteFS, name string, v any) {
+	t.Helper()
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	require.NoError(t, err)
+	require.NoError(t, fsys.WriteFile(name, data, 0o644))
+}
@@ -0,0 +1,189 @@
+package manage
+
+import (
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRegistry(t *testing.T) {
+	reg, err := LoadRegistry("testdata")
+	require.NoError(t, err)
+
+	require.Equal(t, []config.Superchain{config.SepoliaSuperchain}, reg.Superchains())
+	def, ok := reg.SuperchainDefinition(config.SepoliaSuperchain)
+	require.True(t, ok)
+	require.Equal(t, uint64(11155111), def.L1.ChainID)
+	_, ok = reg.SuperchainDefinition(config.MainnetSuperchain)
+	require.False(t, ok)
+
+	collected, err := CollectChainConfigs(paths.SuperchainConfigsDir("testdata"))
+	require.NoError(t, err)
+	require.Equal(t, collected, reg.Chains())
+	require.Equal(t, collected, reg.ChainsBySuperchain(config.SepoliaSuperchain))
+	require.Empty(t, reg.ChainsBySuperchain(config.MainnetSuperchain))
+
+	chain, ok := reg.ChainByID(11155420)
+	require.True(t, ok)
+	require.Equal(t, "op", chain.ShortName)
+	chain, ok = reg.ChainByShortName(config.SepoliaSuperchain, "testchain")
+	require.True(t, ok)
+	require.Equal(t, uint64(1952805748), chain.Config.ChainID)
+	_, ok = reg.ChainByID(1)
+	require.False(t, ok)
+	_, ok = reg.ChainByShortName(config.MainnetSuperchain, "op")
+	require.False(t, ok)
+
+	require.Equal(t, loadTestAddressesJSON(t), reg.Addresses())
+	require.Equal(t, loadTestChainList(t), reg.ChainList())
+
+	// Callers may change the returned collections without affecting the index.
+	delete(reg.Addresses(), "11155420")
+	reg.ChainList()[0].ChainID = 1
+	require.Equal(t, loadTestAddressesJSON(t), reg.Addresses())
+	require.Equal(t, loadTestChainList(t), reg.ChainList())
+
+	_, err = LoadRegistry(t.TempDir())
+	require.Error(t, err)
+}
+
+func TestRegistryReload(t *testing.T) {
+	wd := t.TempDir()
+	writeFile := func(p string, data string) {
+		require.NoError(t, paths.EnsureDir(filepath.Dir(p)))
+		require.NoError(t, os.WriteFile(p, []byte(data), 0o644))
+	}
+	writeFile(paths.SuperchainConfig(wd, config.SepoliaSuperchain), "name = \"Sepolia\"\n")
+	writeFile(paths.ChainConfig(wd, config.SepoliaSuperchain, "a"), "name = \"A\"\nchain_id = 1\n")
+	writeFile(paths.ChainConfig(wd, config.SepoliaSuperchain, "b"), "name = \"B\"\nchain_id = 2\n")
+
+	reg, err := LoadRegistry(wd)
+	require.NoError(t, err)
+	require.Empty(t, reg.Addresses())
+	require.Empty(t, reg.ChainList())
+	a, ok := reg.ChainByID(1)
+	require.True(t, ok)
+	b, ok := reg.ChainByID(2)
+	require.True(t, ok)
+	def, ok := reg.SuperchainDefinition(config.SepoliaSuperchain)
+	require.True(t, ok)
+
+	writeFile(paths.ChainConfig(wd, config.SepoliaSuperchain, "b"), "name = \"B\"\nchain_id = 3\n")
+	writeFile(paths.ChainConfig(wd, config.SepoliaSuperchain, "c"), "name = \"C\"\nchain_id = 4\n")
+	writeFile(paths.ChainListJsonFile(wd), "[{\"name\": \"A\", \"chainId\": 1}]")
+	require.NoError(t, reg.Reload())
+
+	// Unchanged files are not parsed again.
+	reloadedA, ok := reg.ChainByID(1)
+	require.True(t, ok)
+	require.Same(t, a.Config, reloadedA.Config)
+	reloadedDef, ok := reg.SuperchainDefinition(config.SepoliaSuperchain)
+	require.True(t, ok)
+	require.Same(t, def, reloadedDef)
+
+	_, ok = reg.ChainByID(2)
+	require.False(t, ok)
+	reloadedB, ok := reg.ChainByShortName(config.SepoliaSuperchain, "b")
+	require.True(t, ok)
+	require.NotSame(t, b.Config, reloadedB.Config)
+	require.Equal(t, uint64(3), reloadedB.Config.ChainID)
+	_, ok = reg.ChainByID(4)
+	require.True(t, ok)
+	require.Len(t, reg.Chains(), 3)
+	require.Len(t, reg.ChainList(), 1)
+
+	// A failed reload leaves the registry unchanged.
+	writeFile(paths.ChainConfig(wd, config.SepoliaSuperchain, "d"), "name = \"D\"\nchain_id = 1\n")
+	require.ErrorContains(t, reg.Reload(), "duplicate chain ID 1")
+	require.Len(t, reg.Chains(), 3)
+
+	require.NoError(t, os.Remove(paths.ChainConfig(wd, config.SepoliaSuperchain, "d")))
+	writeFile(paths.ChainConfig(wd, config.SepoliaSuperchain, "e"), "name = \"E\"\nchain_id = 5\nunknown_key = true\n")
+	require.NoError(t, reg.Reload())
+	_, err = LoadRegistryStrict(wd)
+	var unknownKeysErr *paths.UnknownKeysError
+	require.ErrorAs(t, err, &unknownKeysErr)
+
+	require.NoError(t, os.Remove(paths.ChainConfig(wd, config.SepoliaSuperchain, "e")))
+	writeFile(paths.SuperchainConfig(wd, config.SepoliaSuperchain), "name = \"Sepolia\"\n[hardforks]\nholocen_time = 0\n")
+	require.NoError(t, reg.Reload())
+	_, err = LoadRegistryStrict(wd)
+	require.ErrorAs(t, err, &unknownKeysErr)
+	require.Equal(t, []string{"hardforks.holocen_time"}, unknownKeysErr.Keys)
+}
+
+func TestLoadRegistryFS(t *testing.T) {
+	fsys := fs.NewMemFS()
+	require.NoError(t, fsys.WriteFile(paths.SuperchainConfig(".", config.SepoliaSuperchain), []byte("name = \"Sepolia\"\n"), 0o644))
+	require.NoError(t, fsys.WriteFile(paths.ChainConfig(".", config.SepoliaSuperchain, "a"), []byte("name = \"A\"\nchain_id = 1\n"), 0o644))
+
+	reg, err := LoadRegistryFS(fsys, true)
+	require.NoError(t, err)
+	require.Same(t, fsys, reg.FS())
+	a, ok := reg.ChainByID(1)
+	require.True(t, ok)
+	require.Equal(t, "a", a.ShortName)
+	require.Equal(t, config.SepoliaSuperchain, a.Superchain)
+	require.Equal(t, paths.ChainConfig(".", config.SepoliaSuperchain, "a"), a.Filepath)
+
+	require.NoError(t, fsys.WriteFile(paths.ChainConfig(".", config.SepoliaSuperchain, "b"), []byte("name = \"B\"\nchain_id = 2\n"), 0o644))
+	require.NoError(t, reg.Reload())
+	require.Len(t, reg.Chains(), 2)
+
+	// Chain configs on disk keep their path on disk.
+	reg, err = LoadRegistry("testdata")
+	require.NoError(t, err)
+	chain, ok := reg.ChainByID(11155420)
+	require.True(t, ok)
+	require.Equal(t, paths.ChainConfig("testdata", config.SepoliaSuperchain, "op"), chain.Filepath)
+}
+
+func TestLoadRegistryOverlay(t *testing.T) {
+	base := fs.NewDirFS("testdata")
+	overlay := fs.NewMemFS()
+	require.NoError(t, overlay.WriteFile(paths.SuperchainConfig(".", "devnet"), []byte("name = \"Devnet\"\n"), 0o644))
+	require.NoError(t, overlay.WriteFile(paths.ChainConfig(".", "devnet", "private"), []byte("name = \"Private\"\nchain_id = 424242\n"), 0o644))
+	require.NoError(t, overlay.WriteFile(paths.ChainListJsonFile("."), []byte("[]"), 0o644))
+
+	reg, err := LoadRegistryOverlay(base, []iofs.FS{overlay}, false)
+	require.NoError(t, err)
+	require.Contains(t, reg.Superchains(), "devnet")
+	require.Contains(t, reg.Superchains(), config.SepoliaSuperchain)
+	private, ok := reg.ChainByID(424242)
+	require.True(t, ok)
+	require.Equal(t, paths.ChainConfig(".", "devnet", "private"), private.Filepath)
+	op, ok := reg.ChainByID(11155420)
+	require.True(t, ok)
+	require.Equal(t, paths.ChainConfig("testdata", config.SepoliaSuperchain, "op"), op.Filepath)
+
+	// Generated files of the overlay replace those of the registry.
+	require.Empty(t, reg.ChainList())
+
+	conflicting := fs.NewMemFS()
+	require.NoError(t, conflicting.WriteFile(paths.ChainConfig(".", "devnet", "other"), []byte("name = \"Other\"\nchain_id = 11155420\n"), 0o644))
+	_, err = LoadRegistryOverlay(base, []iofs.FS{overlay, conflicting}, false)
+	require.ErrorIs(t, err, ErrDuplicateChainID)
+
+	conflicting = fs.NewMemFS()
+	require.NoError(t, conflicting.WriteFile(paths.ChainConfig(".", "devnet", "private"), []byte("name = \"Private 2\"\nchain_id = 424243\n"), 0o644))
+	_, err = LoadRegistryOverlay(base, []iofs.FS{overlay, conflicting}, false)
+	require.ErrorIs(t, err, ErrDuplicateShortName)
+
+	// The check commands load the registry strictly, which also covers the
+	// superchain configs of overlays.
+	strictBase := fs.NewMemFS()
+	require.NoError(t, strictBase.WriteFile(paths.SuperchainConfig(".", config.SepoliaSuperchain), []byte("name = \"Sepolia\"\n"), 0o644))
+	_, err = LoadRegistryOverlay(strictBase, []iofs.FS{overlay}, true)
+	require.NoError(t, err)
+	require.NoError(t, overlay.WriteFile(paths.SuperchainConfig(".", "devnet"), []byte("name = \"Devnet\"\nsafer_safe_addr = \"0x00\"\n"), 0o644))
+	_, err = LoadRegistryOverlay(strictBase, []iofs.FS{overlay}, true)
+	var unknownKeysErr *paths.UnknownKeysError
+	require.ErrorAs(t, err, &unknownKeysErr)
+	require.Equal(t, []string{"safer_safe_addr"}, unknownKeysErr.Keys)
+}
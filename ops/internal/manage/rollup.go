@@ -0,0 +1,126 @@
+package manage
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// channelTimeoutBedrock matches the value op-node uses for every chain it loads
+// from the registry.
+const channelTimeoutBedrock = 300
+
+// RollupConfig builds the op-node rollup config for chain, taking the L1 chain
+// ID from its superchain's definition. The result is validated with
+// rollup.Config.Check.
+func RollupConfig(chain *config.Chain, superchain *config.SuperchainDefinition) (*rollup.Config, error) {
+	if chain.BatchInboxAddr == nil {
+		return nil, errors.New("chain config has no batch inbox address")
+	}
+	if chain.Addresses.OptimismPortalProxy == nil {
+		return nil, errors.New("chain config has no OptimismPortalProxy address")
+	}
+	if chain.Addresses.SystemConfigProxy == nil {
+		return nil, errors.New("chain config has no SystemConfigProxy address")
+	}
+
+	regolithTime := uint64(0)
+	out := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L1: eth.BlockID{
+				Hash:   chain.Genesis.L1.Hash,
+				Number: chain.Genesis.L1.Number,
+			},
+			L2: eth.BlockID{
+				Hash:   chain.Genesis.L2.Hash,
+				Number: chain.Genesis.L2.Number,
+			},
+			L2Time: chain.Genesis.L2Time,
+			SystemConfig: eth.SystemConfig{
+				BatcherAddr: common.Address(chain.Genesis.SystemConfig.BatcherAddr),
+				Overhead:    eth.Bytes32(chain.Genesis.SystemConfig.Overhead),
+				Scalar:      eth.Bytes32(chain.Genesis.SystemConfig.Scalar),
+				GasLimit:    chain.Genesis.SystemConfig.GasLimit,
+			},
+		},
+		BlockTime:              chain.BlockTime,
+		MaxSequencerDrift:      chain.MaxSequencerDrift,
+		SeqWindowSize:          chain.SeqWindowSize,
+		ChannelTimeoutBedrock:  channelTimeoutBedrock,
+		L1ChainID:              new(big.Int).SetUint64(superchain.L1.ChainID),
+		L2ChainID:              new(big.Int).SetUint64(chain.ChainID),
+		RegolithTime:           &regolithTime,
+		BatchInboxAddress:      common.Address(*chain.BatchInboxAddr),
+		DepositContractAddress: common.Address(*chain.Addresses.OptimismPortalProxy),
+		L1SystemConfigAddress:  common.Address(*chain.Addresses.SystemConfigProxy),
+		ChainOpConfig:          opGethOptimismConfig(chain),
+	}
+
+	if err := applyRollupHardforks(out, &chain.Hardforks); err != nil {
+		return nil, err
+	}
+
+	if chain.AltDA != nil {
+		out.AltDAConfig = &rollup.AltDAConfig{
+			DAChallengeAddress: common.Address(chain.AltDA.DaChallengeContractAddress),
+			DAChallengeWindow:  chain.AltDA.DaChallengeWindow,
+			DAResolveWindow:    chain.AltDA.DaResolveWindow,
+			CommitmentType:     chain.AltDA.DaCommitmentType,
+		}
+	}
+
+	if err := out.Check(); err != nil {
+		return nil, fmt.Errorf("invalid rollup config: %w", err)
+	}
+	return out, nil
+}
+
+// ReadRollupConfig builds the rollup config for the chain with the given chain
+// ID in the registry at wd.
+func ReadRollupConfig(wd string, chainID uint64) (*rollup.Config, error) {
+	cfg, err := FindChainConfig(wd, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	var superchain config.SuperchainDefinition
+	if err := paths.ReadTOMLFile(paths.SuperchainConfig(wd, cfg.Superchain), &superchain); err != nil {
+		return nil, fmt.Errorf("error reading superchain config for %s: %w", cfg.Superchain, err)
+	}
+	return RollupConfig(cfg.Config, &superchain)
+}
+
+// applyRollupHardforks sets the rollup config activation time of every
+// registry hardfork, as described by config.AllHardforks.
+func applyRollupHardforks(out *rollup.Config, hardforks *config.Hardforks) error {
+	outVal := reflect.ValueOf(out).Elem()
+	for _, fork := range config.AllHardforks {
+		field := outVal.FieldByName(fork.RollupField)
+		if !field.IsValid() {
+			return fmt.Errorf("rollup config has no field %s for hardfork %s", fork.RollupField, fork.Name)
+		}
+		field.Set(reflect.ValueOf(fork.Time(hardforks).U64Ptr()))
+	}
+	return nil
+}
+
+// opGethOptimismConfig returns the op-geth EIP-1559 parameters for chain.
+func opGethOptimismConfig(chain *config.Chain) *params.OptimismConfig {
+	out := &params.OptimismConfig{
+		EIP1559Elasticity:  chain.Optimism.EIP1559Elasticity,
+		EIP1559Denominator: chain.Optimism.EIP1559Denominator,
+	}
+
+	if chain.Optimism.EIP1559DenominatorCanyon != 0 {
+		out.EIP1559DenominatorCanyon = &chain.Optimism.EIP1559DenominatorCanyon
+	}
+	return out
+}
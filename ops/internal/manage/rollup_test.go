@@ -0,0 +1,80 @@
+package manage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollupConfig(t *testing.T) {
+	chain, err := ReadChainConfig("testdata", "sepolia", "op")
+	require.NoError(t, err)
+
+	var superchain config.SuperchainDefinition
+	require.NoError(t, paths.ReadTOMLFile(paths.SuperchainConfig("testdata", "sepolia"), &superchain))
+
+	t.Run("valid", func(t *testing.T) {
+		out, err := RollupConfig(chain, &superchain)
+		require.NoError(t, err)
+
+		require.EqualValues(t, 11155111, out.L1ChainID.Uint64())
+		require.EqualValues(t, 11155420, out.L2ChainID.Uint64())
+		require.Equal(t, common.HexToHash("0x48f520cf4ddaf34c8336e6e490632ea3cf1e5e93b0b2bc6e917557e31845371b"), out.Genesis.L1.Hash)
+		require.EqualValues(t, 4071408, out.Genesis.L1.Number)
+		require.EqualValues(t, 1691802540, out.Genesis.L2Time)
+		require.EqualValues(t, 30000000, out.Genesis.SystemConfig.GasLimit)
+		require.Equal(t, common.HexToAddress("0xff00000000000000000000000000000011155420"), out.BatchInboxAddress)
+		require.Equal(t, common.HexToAddress("0x16Fc5058F25648194471939df75CF27A2fdC48BC"), out.DepositContractAddress)
+		require.Equal(t, common.HexToAddress("0x034edD2A225f7f429A63E0f1D2084B9E0A93b538"), out.L1SystemConfigAddress)
+		require.EqualValues(t, 2, out.BlockTime)
+		require.EqualValues(t, 0, *out.RegolithTime)
+		require.EqualValues(t, 1699981200, *out.CanyonTime)
+		require.EqualValues(t, 1732633200, *out.HoloceneTime)
+		require.Nil(t, out.IsthmusTime)
+		require.Nil(t, out.AltDAConfig)
+		require.EqualValues(t, 250, *out.ChainOpConfig.EIP1559DenominatorCanyon)
+	})
+
+	t.Run("alt-da", func(t *testing.T) {
+		altDAChain := *chain
+		altDAChain.AltDA = &config.AltDA{
+			DaChallengeContractAddress: config.ChecksummedAddress(common.HexToAddress("0x1234")),
+			DaChallengeWindow:          100,
+			DaResolveWindow:            100,
+			DaCommitmentType:           "KeccakCommitment",
+		}
+
+		out, err := RollupConfig(&altDAChain, &superchain)
+		require.NoError(t, err)
+		require.Equal(t, common.HexToAddress("0x1234"), out.AltDAConfig.DAChallengeAddress)
+		require.Equal(t, "KeccakCommitment", out.AltDAConfig.CommitmentType)
+	})
+
+	t.Run("missing portal", func(t *testing.T) {
+		noPortal := *chain
+		noPortal.Addresses.OptimismPortalProxy = nil
+
+		_, err := RollupConfig(&noPortal, &superchain)
+		require.ErrorContains(t, err, "no OptimismPortalProxy address")
+	})
+}
+
+func TestApplyRollupHardforks(t *testing.T) {
+	var hardforks config.Hardforks
+	for i, fork := range config.AllHardforks {
+		fork.SetTime(&hardforks, config.NewHardforkTime(uint64(i+1)))
+	}
+
+	// Every rollup field named in the catalogue must exist on rollup.Config.
+	var out rollup.Config
+	require.NoError(t, applyRollupHardforks(&out, &hardforks))
+	outVal := reflect.ValueOf(out)
+	for i, fork := range config.AllHardforks {
+		require.EqualValues(t, i+1, outVal.FieldByName(fork.RollupField).Elem().Uint(), fork.Name)
+	}
+}
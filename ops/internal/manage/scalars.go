@@ -0,0 +1,64 @@
+package manage
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/validation"
+)
+
+var ErrInvalidFeeScalars = errors.New("invalid fee scalars")
+
+// feeScalarRangeExemptions are chains whose genesis fee scalars are outside the
+// standard ranges. Their scalars must still decode.
+var feeScalarRangeExemptions = map[uint64]bool{
+	177: true, // hashkeychain
+}
+
+// StandardConfigParams returns the standard config params of superchain. Only
+// mainnet and sepolia have standard params.
+func StandardConfigParams(superchain config.Superchain) (validation.ConfigParams, bool) {
+	switch superchain {
+	case config.MainnetSuperchain:
+		return validation.StandardConfigParamsMainnet, true
+	case config.SepoliaSuperchain:
+		return validation.StandardConfigParamsSepolia, true
+	default:
+		return validation.ConfigParams{}, false
+	}
+}
+
+// ValidateFeeScalars checks that a chain's genesis scalar decodes, that it
+// agrees with the decoded scalar fields if they are set, and that the decoded
+// scalars are within params. Every problem is reported.
+func ValidateFeeScalars(cfg *config.Chain, params validation.EcotoneGasPriceOracleParams) error {
+	sysCfg := cfg.Genesis.SystemConfig
+	scalars, err := sysCfg.EcotoneScalars()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFeeScalars, err)
+	}
+
+	var errs []error
+	report := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrInvalidFeeScalars, fmt.Sprintf(format, args...)))
+	}
+
+	if sysCfg.BaseFeeScalar != nil && *sysCfg.BaseFeeScalar != uint64(scalars.BaseFeeScalar) {
+		report("baseFeeScalar %d does not match scalar %s", *sysCfg.BaseFeeScalar, sysCfg.Scalar)
+	}
+	if sysCfg.BlobBaseFeeScalar != nil && *sysCfg.BlobBaseFeeScalar != uint64(scalars.BlobBaseFeeScalar) {
+		report("blobBaseFeeScalar %d does not match scalar %s", *sysCfg.BlobBaseFeeScalar, sysCfg.Scalar)
+	}
+
+	if !feeScalarRangeExemptions[cfg.ChainID] {
+		if !params.BaseFeeScalar.WithinRange(int(scalars.BaseFeeScalar)) {
+			report("base fee scalar %d is not between %d and %d", scalars.BaseFeeScalar, params.BaseFeeScalar[0], params.BaseFeeScalar[1])
+		}
+		if !params.BlobBaseFeeScalar.WithinRange(int(scalars.BlobBaseFeeScalar)) {
+			report("blob base fee scalar %d is not between %d and %d", scalars.BlobBaseFeeScalar, params.BlobBaseFeeScalar[0], params.BlobBaseFeeScalar[1])
+		}
+	}
+
+	return errors.Join(errs...)
+}
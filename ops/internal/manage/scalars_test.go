@@ -0,0 +1,124 @@
+package manage
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum-optimism/superchain-registry/validation"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFeeScalars(t *testing.T) {
+	params := validation.StandardConfigParamsMainnet.GasPriceOracle.Ecotone
+	u64 := func(v uint64) *uint64 { return &v }
+
+	chain := func(scalars eth.EcotoneScalars) *config.Chain {
+		return &config.Chain{
+			ChainID: 57073,
+			Genesis: config.Genesis{
+				SystemConfig: config.SystemConfig{
+					Scalar: common.Hash(eth.EncodeScalar(scalars)),
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		cfg    *config.Chain
+		mutate func(cfg *config.Chain)
+		errs   []string
+	}{
+		{
+			name: "valid ecotone scalar",
+			cfg:  chain(eth.EcotoneScalars{BaseFeeScalar: 1368, BlobBaseFeeScalar: 810_949}),
+		},
+		{
+			name: "valid bedrock scalar",
+			cfg:  chain(eth.EcotoneScalars{}),
+			mutate: func(cfg *config.Chain) {
+				cfg.Genesis.SystemConfig.Scalar = common.HexToHash("0x0a6fe0")
+			},
+		},
+		{
+			name: "matching scalar fields",
+			cfg:  chain(eth.EcotoneScalars{BaseFeeScalar: 1368, BlobBaseFeeScalar: 810_949}),
+			mutate: func(cfg *config.Chain) {
+				cfg.Genesis.SystemConfig.BaseFeeScalar = u64(1368)
+				cfg.Genesis.SystemConfig.BlobBaseFeeScalar = u64(810_949)
+			},
+		},
+		{
+			name: "mismatched scalar fields",
+			cfg:  chain(eth.EcotoneScalars{BaseFeeScalar: 1368, BlobBaseFeeScalar: 810_949}),
+			mutate: func(cfg *config.Chain) {
+				cfg.Genesis.SystemConfig.BaseFeeScalar = u64(810_949)
+				cfg.Genesis.SystemConfig.BlobBaseFeeScalar = u64(1368)
+			},
+			errs: []string{
+				"baseFeeScalar 810949 does not match scalar",
+				"blobBaseFeeScalar 1368 does not match scalar",
+			},
+		},
+		{
+			name: "scalars out of range",
+			cfg:  chain(eth.EcotoneScalars{BaseFeeScalar: 20_000_000, BlobBaseFeeScalar: 10_000_001}),
+			errs: []string{
+				"base fee scalar 20000000 is not between 0 and 10000000",
+				"blob base fee scalar 10000001 is not between 0 and 10000000",
+			},
+		},
+		{
+			name: "exempt scalars out of range",
+			cfg:  chain(eth.EcotoneScalars{BaseFeeScalar: 20_000_000, BlobBaseFeeScalar: 10_000_001}),
+			mutate: func(cfg *config.Chain) {
+				cfg.ChainID = 177
+			},
+		},
+		{
+			name: "undecodable scalar",
+			cfg:  chain(eth.EcotoneScalars{}),
+			mutate: func(cfg *config.Chain) {
+				cfg.Genesis.SystemConfig.Scalar[0] = 2
+			},
+			errs: []string{"invalid scalar"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mutate != nil {
+				tt.mutate(tt.cfg)
+			}
+
+			err := ValidateFeeScalars(tt.cfg, params)
+			if len(tt.errs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidFeeScalars)
+			for _, msg := range tt.errs {
+				require.ErrorContains(t, err, msg)
+			}
+		})
+	}
+}
+
+func TestValidateFeeScalarsRepo(t *testing.T) {
+	rootDir, err := paths.FindRepoRoot()
+	require.NoError(t, err)
+
+	cfgs, err := CollectChainConfigs(paths.SuperchainConfigsDir(rootDir))
+	require.NoError(t, err)
+
+	for _, cfg := range cfgs {
+		params, ok := StandardConfigParams(cfg.Superchain)
+		if !ok {
+			continue
+		}
+		err := ValidateFeeScalars(cfg.Config, params.GasPriceOracle.Ecotone)
+		require.NoError(t, err, "chain %s/%s", cfg.Superchain, cfg.ShortName)
+	}
+}
@@ -0,0 +1,274 @@
+package manage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+)
+
+// SchemaError is a schema violation in a TOML file. Line is the line of the
+// offending key, or of its closest enclosing table if the key is missing.
+type SchemaError struct {
+	File    string
+	Line    int
+	Key     string
+	Message string
+}
+
+func (e SchemaError) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s: %s", e.File, e.Line, e.Key, e.Message)
+}
+
+// ValidateConfigSchemas validates every superchain definition and chain config
+// under superchain/configs, and every staged config under .staging, of the
+// registry in fsys against the schema generated for its type.
+func ValidateConfigSchemas(fsys iofs.FS) ([]SchemaError, error) {
+	type target struct {
+		root    string
+		matcher paths.CollectorMatcher
+		schema  *config.Schema
+	}
+	targets := []target{
+		{paths.SuperchainConfigsDir("."), paths.SuperchainDefinitionMatcher(), config.SuperchainDefinitionSchema()},
+		{paths.SuperchainConfigsDir("."), paths.ChainConfigMatcher(), config.ChainSchema()},
+		{paths.StagingDir("."), paths.SuperchainDefinitionMatcher(), config.SuperchainDefinitionSchema()},
+		{paths.StagingDir("."), paths.ChainConfigMatcher(), config.StagedChainSchema()},
+	}
+
+	var out []SchemaError
+	for _, t := range targets {
+		var files []string
+		err := iofs.WalkDir(fsys, t.root, func(fp string, d iofs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && t.matcher(fp) {
+				files = append(files, fp)
+			}
+			return nil
+		})
+		if errors.Is(err, iofs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect files in %s: %w", t.root, err)
+		}
+
+		for _, file := range files {
+			data, err := iofs.ReadFile(fsys, file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", file, err)
+			}
+			out = append(out, validateTOMLSchema(diskPath(fsys, file), data, t.schema)...)
+		}
+	}
+	return out, nil
+}
+
+// ValidateTOMLFileSchema validates a single TOML file against schema. Files that
+// are not valid TOML are reported as a single SchemaError at the parse error's
+// line.
+func ValidateTOMLFileSchema(p string, schema *config.Schema) ([]SchemaError, error) {
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", p, err)
+	}
+	return validateTOMLSchema(p, data, schema), nil
+}
+
+// validateTOMLSchema validates the contents of the TOML file p against schema.
+func validateTOMLSchema(p string, data []byte, schema *config.Schema) []SchemaError {
+	var decoded map[string]any
+	if _, err := toml.Decode(string(data), &decoded); err != nil {
+		line := 1
+		var parseErr toml.ParseError
+		if errors.As(err, &parseErr) {
+			line = parseErr.Position.Line
+		}
+		return []SchemaError{{File: p, Line: line, Message: err.Error()}}
+	}
+
+	lines := tomlKeyLines(data)
+	var out []SchemaError
+	for _, violation := range schema.Validate(decoded) {
+		out = append(out, SchemaError{
+			File:    p,
+			Line:    lookupKeyLine(lines, violation.Key),
+			Key:     violation.Key,
+			Message: violation.Message,
+		})
+	}
+	return out
+}
+
+// lookupKeyLine returns the line of key, falling back to its closest defined
+// ancestor and finally to the first line of the file.
+func lookupKeyLine(lines map[string]int, key string) int {
+	for key != "" {
+		if line, ok := lines[key]; ok {
+			return line
+		}
+		idx := strings.LastIndex(key, ".")
+		if idx == -1 {
+			break
+		}
+		key = key[:idx]
+	}
+	return 1
+}
+
+// tomlKeyLines maps every dotted key path defined in data to the line it is
+// defined on.
+func tomlKeyLines(data []byte) map[string]int {
+	lines := make(map[string]int)
+	for i, key := range tomlLineKeys(data) {
+		if key == nil {
+			continue
+		}
+		joined := strings.Join(key, ".")
+		if _, ok := lines[joined]; !ok {
+			lines[joined] = i + 1
+		}
+	}
+	return lines
+}
+
+// tomlLineKeys returns the dotted key path defined or continued on each line of
+// data, or nil for lines that define no key. The BurntSushi decoder does not
+// expose key positions, so this does a light line-based scan that understands
+// table headers, dotted and quoted keys and multi-line arrays, which covers
+// everything in the registry.
+func tomlLineKeys(data []byte) [][]string {
+	var out [][]string
+	var table, key []string
+	depth := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := stripTOMLComment(scanner.Text())
+		if depth > 0 {
+			depth += bracketDepth(line)
+			out = append(out, key)
+			continue
+		}
+
+		key = nil
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+		case strings.HasPrefix(trimmed, "[["):
+			table = splitTOMLKey(strings.TrimSuffix(strings.TrimPrefix(trimmed, "[["), "]]"))
+			key = table
+		case strings.HasPrefix(trimmed, "["):
+			table = splitTOMLKey(strings.TrimSuffix(strings.TrimPrefix(trimmed, "["), "]"))
+			key = table
+		default:
+			name, value, ok := strings.Cut(trimmed, "=")
+			if ok {
+				key = append(slices.Clone(table), splitTOMLKey(name)...)
+				depth = bracketDepth(value)
+			}
+		}
+		out = append(out, key)
+	}
+	return out
+}
+
+// splitTOMLKey splits a dotted key into its parts, unquoting quoted parts.
+func splitTOMLKey(key string) []string {
+	var parts []string
+	var cur strings.Builder
+	var quote rune
+	for _, r := range strings.TrimSpace(key) {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			cur.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '.':
+			parts = append(parts, strings.TrimSpace(cur.String()))
+			cur.Reset()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	return append(parts, strings.TrimSpace(cur.String()))
+}
+
+// stripTOMLComment removes a trailing comment, ignoring # inside strings.
+func stripTOMLComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// bracketDepth returns the number of unclosed array brackets in a value,
+// ignoring brackets inside strings.
+func bracketDepth(value string) int {
+	var depth int
+	var quote rune
+	for _, r := range value {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		}
+	}
+	return depth
+}
+
+// WriteConfigSchemas writes the JSON Schemas for every config type to dir.
+func WriteConfigSchemas(dir string) error {
+	if err := paths.EnsureDir(dir); err != nil {
+		return fmt.Errorf("error creating schema directory: %w", err)
+	}
+
+	schemas := map[string]*config.Schema{
+		"chain.schema.json":                 config.ChainSchema(),
+		"staged-chain.schema.json":          config.StagedChainSchema(),
+		"superchain-definition.schema.json": config.SuperchainDefinitionSchema(),
+	}
+	for name, schema := range schemas {
+		data, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling %s: %w", name, err)
+		}
+		if err := fs.AtomicWrite(filepath.Join(dir, name), 0o644, append(data, '\n')); err != nil {
+			return fmt.Errorf("error writing %s: %w", name, err)
+		}
+	}
+	return nil
+}
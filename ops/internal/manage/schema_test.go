@@ -0,0 +1,87 @@
+package manage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTOMLFileSchema(t *testing.T) {
+	type schemaTestConfig struct {
+		Name                 string `toml:"name"`
+		ChainID              uint64 `toml:"chain_id"`
+		DataAvailabilityType string `toml:"data_availability_type" jsonschema:"enum=eth-da,enum=alt-da"`
+		Hardforks            struct {
+			HoloceneTime *config.HardforkTime `toml:"holocene_time"`
+		} `toml:"hardforks"`
+		Genesis struct {
+			L1 config.GenesisRef `toml:"l1"`
+		} `toml:"genesis"`
+		Roles struct {
+			Guardian *config.ChecksummedAddress `toml:"Guardian"`
+		} `toml:"roles"`
+	}
+	schema := config.GenerateSchema("test", schemaTestConfig{})
+
+	data := `name = "Test" # comment with = and [brackets]
+chain_id = "10"
+data_availability_type = "celestia"
+
+[hardforks]
+  holocen_time = 1
+
+[genesis.l1]
+  hash = "0x1234"
+
+[roles]
+  Guardian = "0x0000000000000000000000000000000000000000"
+`
+	p := filepath.Join(t.TempDir(), "test.toml")
+	require.NoError(t, os.WriteFile(p, []byte(data), 0o644))
+
+	errs, err := ValidateTOMLFileSchema(p, schema)
+	require.NoError(t, err)
+	require.Equal(t, []SchemaError{
+		{File: p, Line: 2, Key: "chain_id", Message: "expected an integer, got a string"},
+		{File: p, Line: 3, Key: "data_availability_type", Message: `"celestia" is not one of eth-da, alt-da`},
+		{File: p, Line: 8, Key: "genesis.l1.number", Message: "missing required key"},
+		{File: p, Line: 9, Key: "genesis.l1.hash", Message: `"0x1234" is not a valid hash`},
+		{File: p, Line: 6, Key: "hardforks.holocen_time", Message: "unknown key"},
+	}, errs)
+	require.Equal(t, p+":6: hardforks.holocen_time: unknown key", errs[4].Error())
+}
+
+func TestValidateTOMLFileSchemaParseError(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "test.toml")
+	require.NoError(t, os.WriteFile(p, []byte("name = \"Test\"\n\nchain_id = 1x0\nblock_time = 2\n"), 0o644))
+
+	errs, err := ValidateTOMLFileSchema(p, config.ChainSchema())
+	require.NoError(t, err)
+	require.Len(t, errs, 1)
+	require.Equal(t, 3, errs[0].Line)
+}
+
+func TestValidateConfigSchemasFS(t *testing.T) {
+	fsys := fs.NewMemFS()
+	chainFile := paths.ChainConfig(".", config.SepoliaSuperchain, "test")
+	require.NoError(t, fsys.WriteFile(chainFile, []byte("name = \"Test\"\nchain_id = \"1\"\n"), 0o644))
+
+	// Registries without a staging directory are fine.
+	errs, err := ValidateConfigSchemas(fsys)
+	require.NoError(t, err)
+	require.Contains(t, errs, SchemaError{File: chainFile, Line: 2, Key: "chain_id", Message: "expected an integer, got a string"})
+}
+
+func TestValidateConfigSchemas(t *testing.T) {
+	rootDir, err := paths.FindRepoRoot()
+	require.NoError(t, err)
+
+	errs, err := ValidateConfigSchemas(fs.NewDirFS(rootDir))
+	require.NoError(t, err)
+	require.Empty(t, errs)
+}
@@ -3,6 +3,8 @@ package manage
 import (
 	"errors"
 	"fmt"
+	iofs "io/fs"
+	"path"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -40,12 +42,13 @@ func InflateChainConfig(opd *deployer.OpDeployer, st deployer.OpaqueState, state
 
 	cfg := new(config.StagedChain)
 
+	cfg.SchemaVersion = LatestSchemaVersion()
 	cfg.ChainID = uint64(common.HexToHash(chainId).Big().Int64())
 	cfg.BatchInboxAddr = config.NewChecksummedAddress(dc.BatchInboxAddress)
 	cfg.BlockTime = dc.L2BlockTime
 	cfg.SeqWindowSize = dc.SequencerWindowSize
 	cfg.MaxSequencerDrift = dc.MaxSequencerDrift
-	cfg.DataAvailabilityType = "eth-da"
+	cfg.DataAvailabilityType = config.EthDADataAvailabilityType
 	cfg.DeploymentL1ContractsVersion = l1ContractsVersion
 	cfg.DeploymentL2ContractsVersion = l2Contracts
 	cfg.DeploymentTxHash = new(common.Hash)
@@ -71,7 +74,7 @@ func InflateChainConfig(opd *deployer.OpDeployer, st deployer.OpaqueState, state
 			DaCommitmentType:           dc.DACommitmentType,
 		}
 		cfg.Addresses.DAChallengeAddress = config.NewChecksummedAddress(dc.DAChallengeProxy)
-		cfg.DataAvailabilityType = "alt-da"
+		cfg.DataAvailabilityType = config.AltDADataAvailabilityType
 	}
 
 	cfg.Genesis = config.Genesis{
@@ -92,6 +95,10 @@ func InflateChainConfig(opd *deployer.OpDeployer, st deployer.OpaqueState, state
 		},
 	}
 
+	if err := cfg.Genesis.SystemConfig.SetEcotoneScalars(); err != nil {
+		return nil, fmt.Errorf("failed to decode genesis system config scalar: %w", err)
+	}
+
 	roles, err := GetRolesFromState(st, idx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read roles from state: %w", err)
@@ -127,12 +134,10 @@ func CopyDeployConfigHFTimes(src *genesis.UpgradeScheduleDeployConfig, dst *conf
 
 	srcVal := reflect.ValueOf(src).Elem()
 	srcType := srcVal.Type()
-	dstVal := reflect.ValueOf(dst).Elem()
 
 	// Iterate through source struct fields
 	for i := 0; i < srcType.NumField(); i++ {
-		field := srcType.Field(i)
-		fieldName := field.Name
+		fieldName := srcType.Field(i).Name
 
 		// Only process fields that start with "L2Genesis" and end with "TimeOffset"
 		// Also skip Regolith since it's not included in the configs.
@@ -142,31 +147,18 @@ func CopyDeployConfigHFTimes(src *genesis.UpgradeScheduleDeployConfig, dst *conf
 			continue
 		}
 
-		// Extract the hardfork name (e.g., "Canyon" from "L2GenesisCanyonTimeOffset")
-		hardforkName := strings.TrimSuffix(strings.TrimPrefix(fieldName, "L2Genesis"), "TimeOffset")
-
-		// Construct the destination field name by adding "Time" suffix
-		dstFieldName := hardforkName + "Time"
-
-		// Get the destination field
-		dstField := dstVal.FieldByName(dstFieldName)
-
 		// Get the source field value
 		srcField := srcVal.Field(i)
 		if srcField.IsNil() {
 			continue // Skip if source value is nil
 		}
 
-		if !dstField.IsValid() {
-			return fmt.Errorf("destination field %s doesn't exist", dstFieldName)
+		fork, ok := config.HardforkByDeployConfigOffset(fieldName)
+		if !ok {
+			return fmt.Errorf("no hardfork registered for deploy config field %s", fieldName)
 		}
 
-		// Create a new HardforkTime pointer and set its value
-		newHardforkTime := new(config.HardforkTime)
-		*newHardforkTime = config.HardforkTime(srcField.Elem().Uint())
-
-		// Set the destination field
-		dstField.Set(reflect.ValueOf(newHardforkTime))
+		fork.SetTime(dst, config.NewHardforkTime(srcField.Elem().Uint()))
 	}
 
 	return nil
@@ -218,7 +210,7 @@ func StagedChainConfigs(rootP string) ([]*config.StagedChain, error) {
 	for i, cfgFilename := range tomls {
 
 		chainCfg := new(config.StagedChain)
-		if err := paths.ReadTOMLFile(cfgFilename, chainCfg); err != nil {
+		if err := paths.ReadTOMLFileStrict(cfgFilename, chainCfg); err != nil {
 			return nil, fmt.Errorf("failed to read %s: %w", cfgFilename, err)
 		}
 		chainCfg.ShortName = strings.TrimSuffix(filepath.Base(cfgFilename), ".toml")
@@ -227,6 +219,38 @@ func StagedChainConfigs(rootP string) ([]*config.StagedChain, error) {
 	return chainCfgs, nil
 }
 
+// StagedChainConfigsFS is like StagedChainConfigs, but reads the staging
+// directory of the registry in fsys.
+func StagedChainConfigsFS(fsys iofs.FS) ([]*config.StagedChain, error) {
+	var tomls []string
+	err := iofs.WalkDir(fsys, paths.StagingDir("."), func(fp string, d iofs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && paths.ChainConfigMatcher()(fp) {
+			tomls = append(tomls, fp)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, iofs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to collect staged chain configs: %w", err)
+	}
+	if len(tomls) == 0 {
+		return nil, ErrNoStagedConfig
+	}
+
+	chainCfgs := make([]*config.StagedChain, len(tomls))
+	for i, cfgFilename := range tomls {
+		chainCfg := new(config.StagedChain)
+		if err := paths.ReadTOMLFileStrictFS(fsys, cfgFilename, chainCfg); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", cfgFilename, err)
+		}
+		chainCfg.ShortName = strings.TrimSuffix(path.Base(cfgFilename), ".toml")
+		chainCfgs[i] = chainCfg
+	}
+	return chainCfgs, nil
+}
+
 // StagedSuperchainDefinition finds a superchain.toml file in the staging directory
 // (if it exists) and returns the parsed SuperchainDefinition struct.
 func StagedSuperchainDefinition(rootP string) (*config.SuperchainDefinition, error) {
@@ -240,7 +264,7 @@ func StagedSuperchainDefinition(rootP string) (*config.SuperchainDefinition, err
 		return nil, ErrNoStagedSuperchainDefinition
 	}
 	sM := new(config.SuperchainDefinition)
-	err = paths.ReadTOMLFile(files[0], sM)
+	err = paths.ReadTOMLFileStrict(files[0], sM)
 
 	return sM, err
 }
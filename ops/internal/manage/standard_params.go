@@ -0,0 +1,163 @@
+package manage
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"slices"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum-optimism/superchain-registry/validation"
+)
+
+// Names of the standard params checked by CheckStandardParams, as they appear
+// in the standard config params files.
+const (
+	SeqWindowSizeParam = "rollup_config.seq_window_size"
+	BlockTimeParam     = "rollup_config.block_time"
+	GasLimitParam      = "system_config.gas_limit"
+)
+
+var standardParams = []string{SeqWindowSizeParam, BlockTimeParam, GasLimitParam}
+
+// StandardParamsAllowlist lists the chains that are known to deviate from their
+// superchain's standard params, and which params they deviate on.
+type StandardParamsAllowlist struct {
+	Deviations []AllowedDeviation `toml:"deviations"`
+}
+
+type AllowedDeviation struct {
+	ChainID uint64   `toml:"chain_id"`
+	Params  []string `toml:"params"`
+}
+
+// ReadStandardParamsAllowlist reads the allowlist of the registry in fsys.
+func ReadStandardParamsAllowlist(fsys fs.FS) (*StandardParamsAllowlist, error) {
+	allowlist := new(StandardParamsAllowlist)
+	if err := paths.ReadTOMLFileStrictFS(fsys, paths.StandardParamsAllowlistFile("."), allowlist); err != nil {
+		return nil, err
+	}
+	for _, dev := range allowlist.Deviations {
+		for _, param := range dev.Params {
+			if !slices.Contains(standardParams, param) {
+				return nil, fmt.Errorf("unknown param %q allowed for chain %d", param, dev.ChainID)
+			}
+		}
+	}
+	return allowlist, nil
+}
+
+// Allows returns whether chainID may deviate on param. A nil allowlist allows
+// nothing.
+func (a *StandardParamsAllowlist) Allows(chainID uint64, param string) bool {
+	if a == nil {
+		return false
+	}
+	for _, dev := range a.Deviations {
+		if dev.ChainID == chainID && slices.Contains(dev.Params, param) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParamCheck is the result of checking a single chain config value against its
+// standard range. Allowed is set whenever the allowlist covers the param, even
+// if the value is standard, so that stale allowlist entries can be found.
+type ParamCheck struct {
+	Param   string           `json:"param"`
+	Value   uint64           `json:"value"`
+	Range   validation.Range `json:"range"`
+	Allowed bool             `json:"allowed,omitempty"`
+}
+
+// Standard returns whether the value is within its standard range.
+func (c ParamCheck) Standard() bool {
+	return c.Value <= math.MaxInt && c.Range.WithinRange(int(c.Value))
+}
+
+// StandardParamsResult is the result of checking a chain against its
+// superchain's standard params.
+type StandardParamsResult struct {
+	Superchain config.Superchain `json:"superchain"`
+	ShortName  string            `json:"shortName"`
+	ChainID    uint64            `json:"chainId"`
+	Checks     []ParamCheck      `json:"checks"`
+}
+
+// Deviations returns the checks whose values are outside their standard range,
+// whether or not they are allowed.
+func (r StandardParamsResult) Deviations() []ParamCheck {
+	var out []ParamCheck
+	for _, check := range r.Checks {
+		if !check.Standard() {
+			out = append(out, check)
+		}
+	}
+	return out
+}
+
+// Passed returns whether every deviation of the chain is allowed.
+func (r StandardParamsResult) Passed() bool {
+	for _, check := range r.Deviations() {
+		if !check.Allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckStandardParams checks cfgs against the standard params files of the
+// registry in fsys. The params files are validated against the game type
+// registry. Chains in superchains without a standard params file are skipped.
+// Results are in the same order as cfgs.
+func CheckStandardParams(fsys fs.FS, cfgs []DiskChainConfig, allowlist *StandardParamsAllowlist) ([]StandardParamsResult, error) {
+	paramsBySuperchain := make(map[config.Superchain]*validation.ConfigParams)
+	var results []StandardParamsResult
+	for _, cfg := range cfgs {
+		params, ok := paramsBySuperchain[cfg.Superchain]
+		if !ok {
+			params = new(validation.ConfigParams)
+			err := paths.ReadTOMLFileFS(fsys, paths.ValidationsFile(".", cfg.Superchain), params)
+			if errors.Is(err, fs.ErrNotExist) {
+				params = nil
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to read standard params for superchain %s: %w", cfg.Superchain, err)
+			} else if err := params.Validate(); err != nil {
+				return nil, fmt.Errorf("invalid standard params for superchain %s: %w", cfg.Superchain, err)
+			}
+			paramsBySuperchain[cfg.Superchain] = params
+		}
+		if params == nil {
+			continue
+		}
+
+		results = append(results, checkStandardParams(cfg, params, allowlist))
+	}
+	return results, nil
+}
+
+func checkStandardParams(cfg DiskChainConfig, params *validation.ConfigParams, allowlist *StandardParamsAllowlist) StandardParamsResult {
+	chainID := cfg.Config.ChainID
+	check := func(param string, value uint64, rng validation.Range) ParamCheck {
+		return ParamCheck{
+			Param:   param,
+			Value:   value,
+			Range:   rng,
+			Allowed: allowlist.Allows(chainID, param),
+		}
+	}
+
+	return StandardParamsResult{
+		Superchain: cfg.Superchain,
+		ShortName:  cfg.ShortName,
+		ChainID:    chainID,
+		Checks: []ParamCheck{
+			check(SeqWindowSizeParam, cfg.Config.SeqWindowSize, params.RollupConfig.SeqWindowSize),
+			check(BlockTimeParam, cfg.Config.BlockTime, params.RollupConfig.BlockTime),
+			check(GasLimitParam, cfg.Config.Genesis.SystemConfig.GasLimit, params.SystemConfig.GasLimit),
+		},
+	}
+}
@@ -0,0 +1,116 @@
+package manage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum-optimism/superchain-registry/validation"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckStandardParams(t *testing.T) {
+	fsys := fs.NewMemFS()
+	require.NoError(t, fsys.WriteFile(paths.ValidationsFile(".", "sepolia"), []byte(`
+[rollup_config]
+seq_window_size = [3600, 3600]
+block_time = [1, 2]
+
+[optimism_portal_2]
+respected_game_type = 0
+
+[system_config]
+gas_limit = [1000, 60_000_000]
+
+[proofs.permissioned]
+game_type = 1
+`), 0o644))
+	require.NoError(t, fsys.WriteFile(paths.StandardParamsAllowlistFile("."), []byte(`
+[[deviations]]
+chain_id = 902
+params = ["rollup_config.seq_window_size"]
+
+[[deviations]]
+chain_id = 903
+params = ["rollup_config.block_time"]
+`), 0o644))
+
+	allowlist, err := ReadStandardParamsAllowlist(fsys)
+	require.NoError(t, err)
+	require.True(t, allowlist.Allows(902, SeqWindowSizeParam))
+	require.False(t, allowlist.Allows(902, BlockTimeParam))
+
+	chain := func(superchain config.Superchain, chainID, seqWindowSize, blockTime, gasLimit uint64) DiskChainConfig {
+		return DiskChainConfig{
+			Superchain: superchain,
+			ShortName:  "test",
+			Config: &config.Chain{
+				ChainID:       chainID,
+				SeqWindowSize: seqWindowSize,
+				BlockTime:     blockTime,
+				Genesis: config.Genesis{
+					SystemConfig: config.SystemConfig{GasLimit: gasLimit},
+				},
+			},
+		}
+	}
+	results, err := CheckStandardParams(fsys, []DiskChainConfig{
+		chain("sepolia", 901, 3600, 2, 30_000_000),
+		chain("sepolia", 902, 7200, 2, 30_000_000),
+		chain("sepolia", 903, 3600, 2, 100_000_000),
+		chain("sepolia-dev-0", 904, 7200, 2, 30_000_000),
+	}, allowlist)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	require.Equal(t, uint64(901), results[0].ChainID)
+	require.Empty(t, results[0].Deviations())
+	require.True(t, results[0].Passed())
+
+	require.Equal(t, []ParamCheck{
+		{Param: SeqWindowSizeParam, Value: 7200, Range: validation.Range{3600, 3600}, Allowed: true},
+	}, results[1].Deviations())
+	require.True(t, results[1].Passed())
+
+	require.Equal(t, []ParamCheck{
+		{Param: GasLimitParam, Value: 100_000_000, Range: validation.Range{1000, 60_000_000}},
+	}, results[2].Deviations())
+	require.False(t, results[2].Passed())
+	require.True(t, results[2].Checks[1].Allowed)
+	require.True(t, results[2].Checks[1].Standard())
+
+	require.NoError(t, fsys.WriteFile(paths.StandardParamsAllowlistFile("."), []byte(`
+[[deviations]]
+chain_id = 902
+params = ["rollup_config.l1_chain_id"]
+`), 0o644))
+	_, err = ReadStandardParamsAllowlist(fsys)
+	require.ErrorContains(t, err, `unknown param "rollup_config.l1_chain_id" allowed for chain 902`)
+
+	// Params files are checked against the game type registry.
+	require.NoError(t, fsys.WriteFile(paths.ValidationsFile(".", "sepolia"), []byte(`
+[proofs.permissioned]
+game_type = 0
+`), 0o644))
+	_, err = CheckStandardParams(fsys, []DiskChainConfig{chain("sepolia", 901, 3600, 2, 30_000_000)}, nil)
+	require.ErrorContains(t, err, "invalid standard params for superchain sepolia: permissioned game type CANNON is not permissioned")
+}
+
+func TestCheckStandardParamsRepo(t *testing.T) {
+	rootDir, err := paths.FindRepoRoot()
+	require.NoError(t, err)
+
+	allowlist, err := ReadStandardParamsAllowlist(os.DirFS(rootDir))
+	require.NoError(t, err)
+	cfgs, err := CollectChainConfigs(paths.SuperchainConfigsDir(rootDir))
+	require.NoError(t, err)
+
+	results, err := CheckStandardParams(os.DirFS(rootDir), cfgs, allowlist)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	for _, res := range results {
+		require.True(t, res.Passed(), "chain %s/%s deviates from standard params: %+v", res.Superchain, res.ShortName, res.Deviations())
+	}
+}
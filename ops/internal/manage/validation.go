@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
+	"reflect"
+	"slices"
 
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
 	"github.com/ethereum/go-ethereum/common"
@@ -73,7 +75,30 @@ func FetchGlobalChainIDs() (map[uint64]ChainEntry, error) {
 	return out, nil
 }
 
+// legacyGenesisChains are the chains that were migrated from a legacy state, so
+// their genesis does not match their chain config.
+var legacyGenesisChains = map[config.Superchain][]string{
+	config.MainnetSuperchain: {"op", "boba", "celo"},
+	config.SepoliaSuperchain: {"boba"},
+}
+
+// IsLegacyGenesis returns whether the chain was migrated from a legacy state, and
+// is skipped by genesis integrity checks.
+func IsLegacyGenesis(superchain config.Superchain, shortName string) bool {
+	return slices.Contains(legacyGenesisChains[superchain], shortName)
+}
+
 func ValidateGenesisIntegrity(cfg *config.Chain, genesis *core.Genesis) error {
+	gen, err := OpGethGenesis(cfg, genesis)
+	if err != nil {
+		return err
+	}
+	return CheckGenesisHash(cfg, gen)
+}
+
+// OpGethChainConfig builds the op-geth chain config for cfg. Every pre-Bedrock
+// fork and Regolith are active at genesis; later forks follow cfg.Hardforks.
+func OpGethChainConfig(cfg *config.Chain) (*params.ChainConfig, error) {
 	genesisActivation := uint64(0)
 	out := &params.ChainConfig{
 		ChainID:                 new(big.Int).SetUint64(cfg.ChainID),
@@ -93,35 +118,32 @@ func ValidateGenesisIntegrity(cfg *config.Chain, genesis *core.Genesis) error {
 		ArrowGlacierBlock:       common.Big0,
 		GrayGlacierBlock:        common.Big0,
 		MergeNetsplitBlock:      common.Big0,
-		ShanghaiTime:            cfg.Hardforks.CanyonTime.U64Ptr(),  // Shanghai activates with Canyon
-		CancunTime:              cfg.Hardforks.EcotoneTime.U64Ptr(), // Cancun activates with Ecotone
-		PragueTime:              cfg.Hardforks.IsthmusTime.U64Ptr(), // Prague activates with Isthmus
 		BedrockBlock:            common.Big0,
 		RegolithTime:            &genesisActivation,
-		CanyonTime:              cfg.Hardforks.CanyonTime.U64Ptr(),
-		EcotoneTime:             cfg.Hardforks.EcotoneTime.U64Ptr(),
-		FjordTime:               cfg.Hardforks.FjordTime.U64Ptr(),
-		GraniteTime:             cfg.Hardforks.GraniteTime.U64Ptr(),
-		HoloceneTime:            cfg.Hardforks.HoloceneTime.U64Ptr(),
-		IsthmusTime:             cfg.Hardforks.IsthmusTime.U64Ptr(),
-		LagoonTime:              cfg.Hardforks.LagoonTime.U64Ptr(),
-		JovianTime:              cfg.Hardforks.JovianTime.U64Ptr(),
 		TerminalTotalDifficulty: common.Big0,
 		Ethash:                  nil,
 		Clique:                  nil,
 	}
 
-	out.Optimism = &params.OptimismConfig{
-		EIP1559Elasticity:  cfg.Optimism.EIP1559Elasticity,
-		EIP1559Denominator: cfg.Optimism.EIP1559Denominator,
+	if err := applyOpGethHardforks(out, &cfg.Hardforks); err != nil {
+		return nil, err
 	}
 
-	if cfg.Optimism.EIP1559DenominatorCanyon != 0 {
-		out.Optimism.EIP1559DenominatorCanyon = &cfg.Optimism.EIP1559DenominatorCanyon
+	out.Optimism = opGethOptimismConfig(cfg)
+	return out, nil
+}
+
+// OpGethGenesis merges the op-geth chain config built from cfg into genesis, as
+// read from the registry with ReadSuperchainGenesis. The result can be written
+// out as a genesis.json for op-geth.
+func OpGethGenesis(cfg *config.Chain, genesis *core.Genesis) (*core.Genesis, error) {
+	chainCfg, err := OpGethChainConfig(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	genCopy := &core.Genesis{
-		Config:        out,
+	return &core.Genesis{
+		Config:        chainCfg,
 		Nonce:         genesis.Nonce,
 		Timestamp:     genesis.Timestamp,
 		ExtraData:     genesis.ExtraData,
@@ -137,12 +159,50 @@ func ValidateGenesisIntegrity(cfg *config.Chain, genesis *core.Genesis) error {
 		ExcessBlobGas: genesis.ExcessBlobGas,
 		BlobGasUsed:   genesis.BlobGasUsed,
 		StateHash:     genesis.StateHash,
-	}
-	block := genCopy.ToBlock()
+	}, nil
+}
 
+// CheckGenesisHash checks that genesis hashes to the L2 genesis hash in cfg.
+func CheckGenesisHash(cfg *config.Chain, genesis *core.Genesis) error {
+	block := genesis.ToBlock()
 	if block.Hash() != cfg.Genesis.L2.Hash {
 		return fmt.Errorf("%w: expected %s, got %s", ErrGenesisHashMismatch, cfg.Genesis.L2.Hash.Hex(), block.Hash().Hex())
 	}
+	return nil
+}
+
+// ReadOpGethGenesis builds the op-geth genesis for the chain with the given
+// chain ID in the registry at wd.
+func ReadOpGethGenesis(wd string, chainID uint64) (*core.Genesis, error) {
+	cfg, err := FindChainConfig(wd, chainID)
+	if err != nil {
+		return nil, err
+	}
 
+	genesis, err := ReadSuperchainGenesis(wd, cfg.Superchain, cfg.ShortName)
+	if err != nil {
+		return nil, fmt.Errorf("error reading genesis for %s/%s: %w", cfg.Superchain, cfg.ShortName, err)
+	}
+
+	gen, err := OpGethGenesis(cfg.Config, genesis)
+	if err != nil {
+		return nil, fmt.Errorf("error building genesis for %s/%s: %w", cfg.Superchain, cfg.ShortName, err)
+	}
+	return gen, nil
+}
+
+// applyOpGethHardforks sets every op-geth chain config field driven by a
+// registry hardfork, as described by config.AllHardforks.
+func applyOpGethHardforks(out *params.ChainConfig, hardforks *config.Hardforks) error {
+	outVal := reflect.ValueOf(out).Elem()
+	for _, fork := range config.AllHardforks {
+		for _, fieldName := range fork.OpGethFields {
+			field := outVal.FieldByName(fieldName)
+			if !field.IsValid() {
+				return fmt.Errorf("op-geth chain config has no field %s for hardfork %s", fieldName, fork.Name)
+			}
+			field.Set(reflect.ValueOf(fork.Time(hardforks).U64Ptr()))
+		}
+	}
 	return nil
 }
@@ -1,11 +1,14 @@
 package manage
 
 import (
+	"encoding/json"
+	"reflect"
 	"testing"
 
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
 )
 
@@ -100,3 +103,52 @@ func TestValidateGenesisIntegrity(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyOpGethHardforks(t *testing.T) {
+	hardforks := config.Hardforks{
+		CanyonTime:  config.NewHardforkTime(1),
+		EcotoneTime: config.NewHardforkTime(2),
+		IsthmusTime: config.NewHardforkTime(3),
+	}
+
+	var out params.ChainConfig
+	require.NoError(t, applyOpGethHardforks(&out, &hardforks))
+	require.EqualValues(t, 1, *out.ShanghaiTime)
+	require.EqualValues(t, 1, *out.CanyonTime)
+	require.EqualValues(t, 2, *out.CancunTime)
+	require.EqualValues(t, 2, *out.EcotoneTime)
+	require.EqualValues(t, 3, *out.PragueTime)
+	require.EqualValues(t, 3, *out.IsthmusTime)
+	require.Nil(t, out.HoloceneTime)
+
+	// Every op-geth field named in the catalogue must exist on params.ChainConfig.
+	typ := reflect.TypeOf(params.ChainConfig{})
+	for _, fork := range config.AllHardforks {
+		for _, fieldName := range fork.OpGethFields {
+			_, ok := typ.FieldByName(fieldName)
+			require.True(t, ok, "params.ChainConfig has no field %s", fieldName)
+		}
+	}
+}
+
+func TestReadOpGethGenesis(t *testing.T) {
+	cfg, err := ReadChainConfig("testdata", "sepolia", "testchain")
+	require.NoError(t, err)
+
+	gen, err := ReadOpGethGenesis("testdata", cfg.ChainID)
+	require.NoError(t, err)
+	require.Equal(t, cfg.ChainID, gen.Config.ChainID.Uint64())
+	require.Equal(t, cfg.Hardforks.HoloceneTime.U64Ptr(), gen.Config.HoloceneTime)
+	require.NotEmpty(t, gen.Alloc)
+	require.NoError(t, CheckGenesisHash(cfg, gen))
+
+	// The exported genesis.json must hash to the same block once re-read.
+	data, err := json.Marshal(gen)
+	require.NoError(t, err)
+	var decoded core.Genesis
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.NoError(t, CheckGenesisHash(cfg, &decoded))
+
+	_, err = ReadOpGethGenesis("testdata", 1)
+	require.ErrorContains(t, err, "chain with chain ID 1 not found")
+}
@@ -0,0 +1,143 @@
+package manage
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	whoisAddressRegexp = regexp.MustCompile(config.AddressPattern)
+	bareTOMLKeyRegexp  = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// WhoisMatch is a place in the registry where an address appears. File is
+// relative to the repo root and Key is the dotted path of the value within it.
+// ChainID is set when the match belongs to a specific L2 chain. Value is the
+// address exactly as written, which may differ in case from the query.
+type WhoisMatch struct {
+	File    string `json:"file"`
+	Key     string `json:"key"`
+	ChainID uint64 `json:"chainId,omitempty"`
+	Value   string `json:"value"`
+}
+
+// Whois finds every occurrence of addr in the registry at wd: addresses.json,
+// every chain config and superchain definition, and the standard roles and
+// versions files in the validation module. Addresses are compared by value, so
+// lowercase and checksummed spellings both match.
+func Whois(wd string, addr common.Address) ([]WhoisMatch, error) {
+	var out []WhoisMatch
+
+	addrsFile := paths.AddressesFile(wd)
+	var addrs map[string]any
+	if err := paths.ReadJSONFile(addrsFile, &addrs); err != nil {
+		return nil, fmt.Errorf("failed to read addresses: %w", err)
+	}
+	for chainIDStr, chainAddrs := range addrs {
+		chainID, err := strconv.ParseUint(chainIDStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chain ID %q in addresses: %w", chainIDStr, err)
+		}
+		out = append(out, findAddress(wd, addrsFile, chainID, []string{chainIDStr}, chainAddrs, addr)...)
+	}
+
+	roots := []string{paths.SuperchainConfigsDir(wd), paths.ValidationsDir(wd)}
+	for _, root := range roots {
+		exists, err := fs.DirExists(root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if %s exists: %w", root, err)
+		}
+		if !exists {
+			continue
+		}
+
+		files, err := paths.CollectFiles(root, paths.FileExtMatcher(".toml"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect files in %s: %w", root, err)
+		}
+		for _, file := range files {
+			var decoded map[string]any
+			if err := paths.ReadTOMLFile(file, &decoded); err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", file, err)
+			}
+
+			var chainID uint64
+			if id, ok := decoded["chain_id"].(int64); ok && root == paths.SuperchainConfigsDir(wd) {
+				chainID = uint64(id)
+			}
+			out = append(out, findAddress(wd, file, chainID, nil, decoded, addr)...)
+		}
+	}
+
+	slices.SortFunc(out, func(a, b WhoisMatch) int {
+		return cmp.Or(
+			strings.Compare(a.File, b.File),
+			cmp.Compare(a.ChainID, b.ChainID),
+			strings.Compare(a.Key, b.Key),
+		)
+	})
+	return out, nil
+}
+
+// findAddress walks decoded TOML or JSON data and returns every string value
+// equal to addr.
+func findAddress(wd string, file string, chainID uint64, key []string, data any, addr common.Address) []WhoisMatch {
+	var out []WhoisMatch
+	switch v := data.(type) {
+	case map[string]any:
+		for name, child := range v {
+			out = append(out, findAddress(wd, file, chainID, appendKey(key, name), child, addr)...)
+		}
+	case []map[string]any:
+		for i, child := range v {
+			out = append(out, findAddress(wd, file, chainID, appendKey(key, strconv.Itoa(i)), child, addr)...)
+		}
+	case []any:
+		for i, child := range v {
+			out = append(out, findAddress(wd, file, chainID, appendKey(key, strconv.Itoa(i)), child, addr)...)
+		}
+	case string:
+		if !whoisAddressRegexp.MatchString(v) || common.HexToAddress(v) != addr {
+			return nil
+		}
+		rel, err := filepath.Rel(wd, file)
+		if err != nil {
+			rel = file
+		}
+		out = append(out, WhoisMatch{
+			File:    filepath.ToSlash(rel),
+			Key:     joinTOMLKey(key),
+			ChainID: chainID,
+			Value:   v,
+		})
+	}
+	return out
+}
+
+func appendKey(key []string, name string) []string {
+	return append(slices.Clone(key), name)
+}
+
+// joinTOMLKey joins key parts into a dotted key, quoting parts that are not
+// bare TOML keys such as release tags.
+func joinTOMLKey(key []string) string {
+	parts := make([]string, len(key))
+	for i, part := range key {
+		if bareTOMLKeyRegexp.MatchString(part) {
+			parts[i] = part
+		} else {
+			parts[i] = strconv.Quote(part)
+		}
+	}
+	return strings.Join(parts, ".")
+}
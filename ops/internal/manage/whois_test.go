@@ -0,0 +1,64 @@
+package manage
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWhois(t *testing.T) {
+	t.Run("matches case-insensitively", func(t *testing.T) {
+		matches, err := Whois("testdata", common.HexToAddress("0x034edd2a225f7f429a63e0f1d2084b9e0a93b538"))
+		require.NoError(t, err)
+		require.Equal(t, []WhoisMatch{
+			{
+				File:    "superchain/configs/sepolia/op.toml",
+				Key:     "addresses.SystemConfigProxy",
+				ChainID: 11155420,
+				Value:   "0x034edD2A225f7f429A63E0f1D2084B9E0A93b538",
+			},
+			{
+				File:    "superchain/extra/addresses/addresses.json",
+				Key:     "11155420.SystemConfigProxy",
+				ChainID: 11155420,
+				Value:   "0x034edD2A225f7f429A63E0f1D2084B9E0A93b538",
+			},
+		}, matches)
+	})
+
+	t.Run("superchain definitions", func(t *testing.T) {
+		matches, err := Whois("testdata", common.HexToAddress("0xC2Be75506d5724086DEB7245bd260Cc9753911Be"))
+		require.NoError(t, err)
+		require.Contains(t, matches, WhoisMatch{
+			File:  "superchain/configs/sepolia/superchain.toml",
+			Key:   "superchain_config_addr",
+			Value: "0xC2Be75506d5724086DEB7245bd260Cc9753911Be",
+		})
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		matches, err := Whois("testdata", common.HexToAddress("0x000000000000000000000000000000000000dEaD"))
+		require.NoError(t, err)
+		require.Empty(t, matches)
+	})
+
+	t.Run("standard versions", func(t *testing.T) {
+		rootDir, err := paths.FindRepoRoot()
+		require.NoError(t, err)
+
+		matches, err := Whois(rootDir, common.HexToAddress("0x9ce712ff84e02659846dc6450bb9b7642fe8be5d"))
+		require.NoError(t, err)
+		require.Contains(t, matches, WhoisMatch{
+			File:  "validation/standard/standard-versions-mainnet.toml",
+			Key:   `"op-contracts/v7.0.0-rc.4".op_contracts_manager.address`,
+			Value: "0x9ce712ff84e02659846dc6450bb9b7642fe8be5d",
+		})
+	})
+}
+
+func TestJoinTOMLKey(t *testing.T) {
+	require.Equal(t, "addresses.SystemConfigProxy", joinTOMLKey([]string{"addresses", "SystemConfigProxy"}))
+	require.Equal(t, `"op-contracts/v1.6.0".mips.address`, joinTOMLKey([]string{"op-contracts/v1.6.0", "mips", "address"}))
+}
@@ -8,7 +8,6 @@ import (
 	"path"
 	"path/filepath"
 
-	"github.com/BurntSushi/toml"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
 )
 
@@ -52,7 +51,19 @@ func ChainConfig(wd string, superchain config.Superchain, shortName string) stri
 	return path.Join(SuperchainDir(wd, superchain), shortName+".toml")
 }
 
+// SuperchainIds returns the L1 chain ID of every superchain at wd.
 func SuperchainIds(wd string) (map[config.Superchain]uint64, error) {
+	return superchainIds(wd, ReadTOMLFile)
+}
+
+// SuperchainIdsStrict is like SuperchainIds, but fails with an
+// *UnknownKeysError if a superchain config has keys that are not in the
+// schema. It is used by the check commands.
+func SuperchainIdsStrict(wd string) (map[config.Superchain]uint64, error) {
+	return superchainIds(wd, ReadTOMLFileStrict)
+}
+
+func superchainIds(wd string, readTOML func(p string, out any) error) (map[config.Superchain]uint64, error) {
 	superchains, err := Superchains(wd)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get superchains: %w", err)
@@ -60,15 +71,9 @@ func SuperchainIds(wd string) (map[config.Superchain]uint64, error) {
 
 	superchainIds := make(map[config.Superchain]uint64)
 	for _, superchain := range superchains {
-		superchainFile := SuperchainConfig(wd, superchain)
-		data, err := os.ReadFile(superchainFile)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read superchain config: %w", err)
-		}
-
 		var superchainDef config.SuperchainDefinition
-		if err := toml.Unmarshal(data, &superchainDef); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal superchain config: %w", err)
+		if err := readTOML(SuperchainConfig(wd, superchain), &superchainDef); err != nil {
+			return nil, fmt.Errorf("failed to read superchain config: %w", err)
 		}
 		superchainIds[superchain] = superchainDef.L1.ChainID
 	}
@@ -120,6 +125,20 @@ func AddressesFile(wd string) string {
 	return path.Join(ExtraDir(wd), "addresses", "addresses.json")
 }
 
+// L1BlocksFile records the L1 block the onchain data of each chain in the
+// generated files was read at.
+func L1BlocksFile(wd string) string {
+	return path.Join(ExtraDir(wd), "addresses", "l1-blocks.json")
+}
+
+func OnchainSnapshotsDir(wd string) string {
+	return path.Join(ExtraDir(wd), "onchain")
+}
+
+func OnchainSnapshotFile(wd string, chainID uint64) string {
+	return path.Join(OnchainSnapshotsDir(wd), fmt.Sprintf("%d.json", chainID))
+}
+
 func ChainListJsonFile(wd string) string {
 	return path.Join(wd, "chainList.json")
 }
@@ -140,6 +159,10 @@ func ValidationsFile(wd string, superchain string) string {
 	return path.Join(ValidationsDir(wd), fmt.Sprintf("standard-config-params-%s.toml", superchain))
 }
 
+func StandardParamsAllowlistFile(wd string) string {
+	return path.Join(ValidationsDir(wd), "standard-config-params-allowlist.toml")
+}
+
 func RequireDir(p string) error {
 	stat, err := os.Stat(p)
 	if err != nil {
@@ -1,10 +1,14 @@
 package paths
 
 import (
+	"errors"
+	"os"
 	"path"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,3 +21,31 @@ func TestFindRepoRoot(t *testing.T) {
 	_, err = findRepoRootFromDir(tmpDir)
 	require.ErrorContains(t, err, "not in repo")
 }
+
+func TestSuperchainIds(t *testing.T) {
+	wd := t.TempDir()
+	writeSuperchain := func(superchain config.Superchain, data string) {
+		require.NoError(t, os.MkdirAll(SuperchainDir(wd, superchain), 0o755))
+		require.NoError(t, os.WriteFile(SuperchainConfig(wd, superchain), []byte(data), 0o644))
+	}
+	writeSuperchain(config.MainnetSuperchain, "name = \"Mainnet\"\n[l1]\nchain_id = 1\n")
+	writeSuperchain(config.SepoliaSuperchain, "name = \"Sepolia\"\n[l1]\nchain_id = 11155111\n")
+
+	ids, err := SuperchainIds(wd)
+	require.NoError(t, err)
+	require.Equal(t, map[config.Superchain]uint64{
+		config.MainnetSuperchain: 1,
+		config.SepoliaSuperchain: 11155111,
+	}, ids)
+
+	writeSuperchain(config.SepoliaSuperchain, "name = \"Sepolia\"\n[l1]\nchain_id = 11155111\n\n[hardforks]\nholocen_time = 0\n")
+	// Only the check commands reject unknown keys.
+	lenientIds, err := SuperchainIds(wd)
+	require.NoError(t, err)
+	require.Equal(t, ids, lenientIds)
+	_, err = SuperchainIdsStrict(wd)
+	var unknownErr *UnknownKeysError
+	require.True(t, errors.As(err, &unknownErr))
+	require.Equal(t, filepath.Join(wd, "superchain", "configs", "sepolia", "superchain.toml"), unknownErr.Path)
+	require.Equal(t, []string{"hardforks.holocen_time"}, unknownErr.Keys)
+}
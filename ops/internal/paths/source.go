@@ -0,0 +1,117 @@
+package paths
+
+import (
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"strings"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+)
+
+// OpenSource opens the registry that a command should run against. An empty
+// source is the working tree at wd. Otherwise, source is one of:
+//   - git:<rev>, the tree of a revision of the repository at wd;
+//   - the path of a .tar, .tar.gz or .tgz archive of the repository;
+//   - the path of another checkout of the repository.
+//
+// Git revisions and archives are read into memory, so writing to them never
+// touches the disk.
+func OpenSource(wd string, source string) (fs.WriteFS, error) {
+	if source == "" {
+		return fs.NewDirFS(wd), nil
+	}
+
+	if rev, ok := strings.CutPrefix(source, "git:"); ok {
+		tree, err := fs.ReadGitRevision(wd, rev)
+		if err != nil {
+			return nil, err
+		}
+		return requireRegistryRoot(tree, source)
+	}
+
+	if strings.HasSuffix(source, ".tar") || strings.HasSuffix(source, ".tar.gz") || strings.HasSuffix(source, ".tgz") {
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open archive: %w", err)
+		}
+		defer f.Close()
+
+		tree, err := fs.ReadTarball(f)
+		if err != nil {
+			return nil, err
+		}
+		return requireRegistryRoot(archiveRoot(tree), source)
+	}
+
+	if err := RequireDir(SuperchainConfigsDir(source)); err != nil {
+		return nil, fmt.Errorf("%s is not a registry checkout: %w", source, err)
+	}
+	return fs.NewDirFS(source), nil
+}
+
+// OpenOverlays opens overlay directories as DirFSs. Overlays have the same
+// layout as the registry, and are merged over it by manage.LoadRegistryOverlay.
+// Empty entries are skipped.
+func OpenOverlays(dirs []string) ([]iofs.FS, error) {
+	var overlays []iofs.FS
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		if err := RequireDir(SuperchainConfigsDir(dir)); err != nil {
+			return nil, fmt.Errorf("%s is not an overlay directory: %w", dir, err)
+		}
+		overlays = append(overlays, fs.NewDirFS(dir))
+	}
+	return overlays, nil
+}
+
+// archiveRoot returns the registry in tree. Archives usually contain a single
+// top-level directory named after the repository and revision, such as the
+// tarballs GitHub serves, which is stripped.
+func archiveRoot(tree *fs.MemFS) *fs.MemFS {
+	if isRegistryRoot(tree) {
+		return tree
+	}
+
+	entries, err := iofs.ReadDir(tree, ".")
+	if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+		return tree
+	}
+	return tree.Sub(entries[0].Name())
+}
+
+func requireRegistryRoot(tree *fs.MemFS, source string) (*fs.MemFS, error) {
+	if !isRegistryRoot(tree) {
+		return nil, fmt.Errorf("%s does not contain %s", source, SuperchainConfigsDir("."))
+	}
+	return tree, nil
+}
+
+func isRegistryRoot(fsys iofs.FS) bool {
+	stat, err := iofs.Stat(fsys, SuperchainConfigsDir("."))
+	return err == nil && stat.IsDir()
+}
+
+// SuperchainsFS is like Superchains, but reads the registry in fsys.
+func SuperchainsFS(fsys iofs.FS) ([]string, error) {
+	configsDir := SuperchainConfigsDir(".")
+
+	dir, err := iofs.ReadDir(fsys, configsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dir %s: %w", configsDir, err)
+	}
+
+	var superchains []string
+	for _, entry := range dir {
+		if !entry.IsDir() {
+			continue
+		}
+		// only add if we find a superchain.toml file in the dir
+		if _, err := iofs.Stat(fsys, SuperchainConfig(".", entry.Name())); err == nil {
+			superchains = append(superchains, entry.Name())
+		}
+	}
+	return superchains, nil
+}
@@ -0,0 +1,83 @@
+package paths
+
+import (
+	"archive/tar"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenSource(t *testing.T) {
+	wd := t.TempDir()
+	require.NoError(t, os.MkdirAll(SuperchainDir(wd, "sepolia"), 0o755))
+	require.NoError(t, os.WriteFile(SuperchainConfig(wd, "sepolia"), []byte("name = \"Sepolia\"\n"), 0o644))
+
+	t.Run("working tree", func(t *testing.T) {
+		src, err := OpenSource(wd, "")
+		require.NoError(t, err)
+		require.Equal(t, wd, src.(*fs.DirFS).Dir())
+	})
+
+	t.Run("checkout", func(t *testing.T) {
+		src, err := OpenSource(t.TempDir(), wd)
+		require.NoError(t, err)
+		superchains, err := SuperchainsFS(src)
+		require.NoError(t, err)
+		require.Equal(t, []string{"sepolia"}, superchains)
+
+		_, err = OpenSource(wd, t.TempDir())
+		require.ErrorContains(t, err, "is not a registry checkout")
+	})
+
+	t.Run("archive", func(t *testing.T) {
+		writeArchive := func(name string, files map[string]string) string {
+			p := filepath.Join(t.TempDir(), name)
+			f, err := os.Create(p)
+			require.NoError(t, err)
+			defer f.Close()
+			tw := tar.NewWriter(f)
+			for name, data := range files {
+				require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(data))}))
+				_, err := tw.Write([]byte(data))
+				require.NoError(t, err)
+			}
+			require.NoError(t, tw.Close())
+			return p
+		}
+
+		// The top-level directory of the archive is stripped.
+		src, err := OpenSource(wd, writeArchive("registry.tar", map[string]string{
+			"superchain-registry-main/superchain/configs/sepolia/superchain.toml": "name = \"Sepolia\"\n",
+			"superchain-registry-main/chainList.json":                             "[]",
+		}))
+		require.NoError(t, err)
+		data, err := iofs.ReadFile(src, ChainListJsonFile("."))
+		require.NoError(t, err)
+		require.Equal(t, "[]", string(data))
+
+		_, err = OpenSource(wd, writeArchive("other.tar", map[string]string{
+			"other/README.md": "",
+		}))
+		require.ErrorContains(t, err, "does not contain superchain/configs")
+
+		_, err = OpenSource(wd, filepath.Join(t.TempDir(), "missing.tgz"))
+		require.ErrorContains(t, err, "failed to open archive")
+	})
+}
+
+func TestOpenOverlays(t *testing.T) {
+	overlay := t.TempDir()
+	require.NoError(t, os.MkdirAll(SuperchainConfigsDir(overlay), 0o755))
+
+	overlays, err := OpenOverlays([]string{"", overlay})
+	require.NoError(t, err)
+	require.Len(t, overlays, 1)
+	require.Equal(t, overlay, overlays[0].(*fs.DirFS).Dir())
+
+	_, err = OpenOverlays([]string{t.TempDir()})
+	require.ErrorContains(t, err, "is not an overlay directory")
+}
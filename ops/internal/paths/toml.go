@@ -1,18 +1,32 @@
 package paths
 
 import (
+	"bytes"
 	"compress/gzip"
 	"encoding/json"
 	"fmt"
 	"io"
+	iofs "io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/fs"
 	"gopkg.in/yaml.v3"
 )
 
+// UnknownKeysError is returned by strict TOML decoding when a file contains
+// keys that do not map to any field of the target struct.
+type UnknownKeysError struct {
+	Path string
+	Keys []string
+}
+
+func (e *UnknownKeysError) Error() string {
+	return fmt.Sprintf("unknown keys in %s: %s", e.Path, strings.Join(e.Keys, ", "))
+}
+
 func ReadTOMLFile(p string, out any) error {
 	data, err := os.ReadFile(p)
 	if err != nil {
@@ -26,6 +40,62 @@ func ReadTOMLFile(p string, out any) error {
 	return nil
 }
 
+// ReadTOMLFileStrict is like ReadTOMLFile, but fails with an *UnknownKeysError
+// if the file contains keys that out has no field for.
+func ReadTOMLFileStrict(p string, out any) error {
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return fmt.Errorf("failed to read TOML file: %w", err)
+	}
+
+	return UnmarshalTOMLStrict(p, data, out)
+}
+
+// ReadTOMLFileFS is like ReadTOMLFile, but reads name from fsys.
+func ReadTOMLFileFS(fsys iofs.FS, name string, out any) error {
+	data, err := iofs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("failed to read TOML file: %w", err)
+	}
+
+	if err := toml.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to unmarshal TOML: %w", err)
+	}
+
+	return nil
+}
+
+// ReadTOMLFileStrictFS is like ReadTOMLFileStrict, but reads name from fsys.
+func ReadTOMLFileStrictFS(fsys iofs.FS, name string, out any) error {
+	data, err := iofs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("failed to read TOML file: %w", err)
+	}
+
+	return UnmarshalTOMLStrict(name, data, out)
+}
+
+// UnmarshalTOMLStrict decodes data into out and returns an *UnknownKeysError
+// listing the full path of every key that out has no field for. p is only used
+// to identify the file in errors.
+func UnmarshalTOMLStrict(p string, data []byte, out any) error {
+	md, err := toml.NewDecoder(bytes.NewReader(data)).Decode(out)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal TOML: %w", err)
+	}
+
+	undecoded := md.Undecoded()
+	if len(undecoded) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(undecoded))
+	for i, key := range undecoded {
+		keys[i] = key.String()
+	}
+	return &UnknownKeysError{Path: p, Keys: keys}
+}
+
 func ReadJSONFile(p string, out any) error {
 	f, err := os.Open(p)
 	if err != nil {
@@ -52,6 +122,21 @@ func ReadJSONFile(p string, out any) error {
 	return nil
 }
 
+// ReadJSONFileFS is like ReadJSONFile, but reads name from fsys. Gzipped files
+// are not supported.
+func ReadJSONFileFS(fsys iofs.FS, name string, out any) error {
+	data, err := iofs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("failed to read JSON file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	return nil
+}
+
 func ReadYAMLFile(p string, out any) error {
 	data, err := os.ReadFile(p)
 	if err != nil {
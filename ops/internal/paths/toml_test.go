@@ -0,0 +1,49 @@
+package paths
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type strictTestConfig struct {
+	Name      string `toml:"name"`
+	Hardforks struct {
+		HoloceneTime *uint64 `toml:"holocene_time"`
+	} `toml:"hardforks"`
+}
+
+func TestReadTOMLFileStrict(t *testing.T) {
+	t.Run("known keys", func(t *testing.T) {
+		p := writeTestTOML(t, "name = \"test\"\n\n[hardforks]\nholocene_time = 1\n")
+
+		var cfg strictTestConfig
+		require.NoError(t, ReadTOMLFileStrict(p, &cfg))
+		require.Equal(t, "test", cfg.Name)
+		require.Equal(t, uint64(1), *cfg.Hardforks.HoloceneTime)
+	})
+
+	t.Run("unknown keys", func(t *testing.T) {
+		p := writeTestTOML(t, "name = \"test\"\nsafer_safes_addr = \"0x00\"\n\n[hardforks]\nholocen_time = 1\n")
+
+		var cfg strictTestConfig
+		err := ReadTOMLFileStrict(p, &cfg)
+		var unknownErr *UnknownKeysError
+		require.True(t, errors.As(err, &unknownErr))
+		require.Equal(t, p, unknownErr.Path)
+		require.Equal(t, []string{"safer_safes_addr", "hardforks.holocen_time"}, unknownErr.Keys)
+		require.ErrorContains(t, err, "hardforks.holocen_time")
+
+		// The lenient reader keeps ignoring unknown keys.
+		require.NoError(t, ReadTOMLFile(p, &cfg))
+	})
+}
+
+func writeTestTOML(t *testing.T, data string) string {
+	p := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(p, []byte(data), 0o644))
+	return p
+}
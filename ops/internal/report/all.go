@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
 	"github.com/ethereum-optimism/superchain-registry/ops/internal/deployer"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/l1block"
 	"github.com/ethereum-optimism/superchain-registry/validation"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -17,6 +18,7 @@ func ScanAll(
 	ctx context.Context,
 	l1RpcUrl string,
 	rpcClient *rpc.Client,
+	l1Block l1block.Spec,
 	statePath string,
 	chainCfg *config.StagedChain,
 	deployerCacheDir string,
@@ -31,20 +33,35 @@ func ScanAll(
 		}
 	}
 
+	// Every read of the report is made at the same L1 block, so that the L1 and
+	// L2 scans agree and the report can be reproduced.
+	block, err := l1block.Resolve(ctx, rpcClient, l1Block)
+	if err != nil {
+		err = fmt.Errorf("failed to resolve L1 block: %w", err)
+		return Report{
+			L1Err: err,
+			L2Err: err,
+		}
+	}
+	report.L1Block = &block
+
 	report.L1, err = ScanL1(
 		ctx,
 		rpcClient,
+		block.BigNumber(),
 		*chainCfg.DeploymentTxHash,
 		l1ContractsRelease,
+		(*common.Address)(chainCfg.GasPayingToken),
 	)
 	if err != nil {
 		report.L1Err = err
 	}
 
-	report.L2, err = ScanL2(
+	report.L2, err = scanL2AtBlock(
 		statePath,
 		chainCfg.ChainID,
 		l1RpcUrl,
+		block,
 		deployerCacheDir,
 		l1ContractsRelease,
 	)
@@ -56,6 +73,25 @@ func ScanAll(
 	return report
 }
 
+// scanL2AtBlock runs ScanL2 against a proxy of the L1 RPC that pins
+// op-deployer's reads to block.
+func scanL2AtBlock(
+	statePath string,
+	l2ChainId uint64,
+	l1RpcUrl string,
+	block l1block.Ref,
+	deployerCacheDir string,
+	l1ContractsVersion string,
+) (*L2Report, error) {
+	proxy, err := l1block.NewProxy(l1RpcUrl, block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pin L1 RPC: %w", err)
+	}
+	defer proxy.Close()
+
+	return ScanL2(statePath, l2ChainId, proxy.URL(), deployerCacheDir, l1ContractsVersion)
+}
+
 func GetContractsReleaseForOpcm(statePath string) (string, error) {
 	// Load state.json
 	st, err := deployer.ReadOpaqueStateFile(statePath)
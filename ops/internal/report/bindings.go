@@ -3,6 +3,7 @@ package report
 import (
 	"context"
 	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/lmittmann/w3"
@@ -56,6 +57,12 @@ var (
 	gasPayingTokenSymbolABI = w3.MustNewFunc("gasPayingTokenSymbol()", "string")
 
 	minBaseFeeABI = w3.MustNewFunc("minBaseFee()", "uint64")
+
+	erc20NameABI = w3.MustNewFunc("name()", "string")
+
+	erc20SymbolABI = w3.MustNewFunc("symbol()", "string")
+
+	erc20DecimalsABI = w3.MustNewFunc("decimals()", "uint8")
 )
 
 type BatchCall struct {
@@ -64,7 +71,9 @@ type BatchCall struct {
 	Decoder func([]byte) error
 }
 
-func CallBatch(ctx context.Context, w3c *w3.Client, calls ...BatchCall) error {
+// CallBatch makes calls in a single batch at the given L1 block, or at the
+// latest block if block is nil.
+func CallBatch(ctx context.Context, w3c *w3.Client, block *big.Int, calls ...BatchCall) error {
 	batchCalls := make([]w3types.RPCCaller, len(calls))
 	rawOutputs := make([][]byte, len(calls))
 
@@ -78,7 +87,7 @@ func CallBatch(ctx context.Context, w3c *w3.Client, calls ...BatchCall) error {
 			To:    &call.To,
 			Input: input,
 		}
-		batchCalls[i] = eth.Call(msg, nil, nil).Returns(&rawOutputs[i])
+		batchCalls[i] = eth.Call(msg, block, nil).Returns(&rawOutputs[i])
 	}
 
 	if err := w3c.CallCtx(ctx, batchCalls...); err != nil {
@@ -40,6 +40,9 @@ var funcMap = template.FuncMap{
 		}
 		return "⚠️"
 	},
+	"gameType": func(id uint32) validation.GameType {
+		return validation.GameType(id)
+	},
 	"checkmarkUint64": func(a, b uint64) string {
 		if a == b {
 			return "✅"
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/l1block"
 	"github.com/ethereum-optimism/superchain-registry/validation"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
@@ -37,6 +38,7 @@ func TestRenderComment(t *testing.T) {
 			&Report{
 				L1:          &l1Report,
 				L2:          l2Report,
+				L1Block:     &l1block.Ref{Number: 7654321, Hash: common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111")},
 				GeneratedAt: time.Unix(1234, 0),
 			},
 			validation.StandardConfigParamsSepolia,
@@ -21,8 +21,10 @@ import (
 func ScanL1(
 	ctx context.Context,
 	rpcClient *rpc.Client,
+	block *big.Int,
 	deploymentTx common.Hash,
 	release string,
+	gasPayingToken *common.Address,
 ) (*L1Report, error) {
 	client := ethclient.NewClient(rpcClient)
 
@@ -64,22 +66,27 @@ func ScanL1(
 	}
 	output.WriteOK("deployment transaction was sent to the expected OPCM address: %v", opcmAddr)
 
-	semversReport, err := ScanSemvers(ctx, rpcClient, deployedEvent.DeployOutput)
+	semversReport, err := ScanSemvers(ctx, rpcClient, block, deployedEvent.DeployOutput)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate semvers: %w", err)
 	}
 	output.WriteOK("validated semvers")
 
-	ownershipReport, err := ScanOwnership(ctx, rpcClient, deployedEvent.DeployOutput)
+	ownershipReport, err := ScanOwnership(ctx, rpcClient, block, deployedEvent.DeployOutput)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate ownership: %w", err)
 	}
 	output.WriteOK("validated ownership")
 
+	permissionedGameType, err := standardPermissionedGameType(chainID.Uint64())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permissioned game type: %w", err)
+	}
 	permissionedGameReport, err := ScanFDG(
 		ctx,
 		rpcClient,
-		1, // PERMISSIONED
+		block,
+		permissionedGameType,
 		deployedEvent.DeployOutput.DisputeGameFactoryProxy,
 		deployedEvent.DeployOutput.PermissionedDisputeGame,
 	)
@@ -87,11 +94,20 @@ func ScanL1(
 		return nil, fmt.Errorf("failed to validate permissioned dispute game: %w", err)
 	}
 
-	systemConfigReport, err := ScanSystemConfig(ctx, rpcClient, release, deployedEvent.DeployOutput.SystemConfigProxy)
+	systemConfigReport, err := ScanSystemConfig(ctx, rpcClient, block, release, deployedEvent.DeployOutput.SystemConfigProxy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate system config: %w", err)
 	}
 
+	var gasPayingTokenReport *L1GasPayingTokenReport
+	if gasPayingToken != nil {
+		tokenReport, err := ScanGasPayingToken(ctx, rpcClient, block, deployedEvent.DeployOutput.SystemConfigProxy, *gasPayingToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate gas paying token: %w", err)
+		}
+		gasPayingTokenReport = &tokenReport
+	}
+
 	return &L1Report{
 		Release:           release,
 		DeploymentTxHash:  deploymentTx,
@@ -101,7 +117,8 @@ func ScanL1(
 		Proofs: L1ProofsReport{
 			Permissioned: permissionedGameReport,
 		},
-		SystemConfig: systemConfigReport,
+		SystemConfig:   systemConfigReport,
+		GasPayingToken: gasPayingTokenReport,
 	}, nil
 }
 
@@ -129,9 +146,21 @@ func opcmImplAddressFor(chainID uint64, tag string) (common.Address, error) {
 	return common.Address{}, fmt.Errorf("OPContractsManager address is nil for tag %s", tag)
 }
 
+func standardPermissionedGameType(chainID uint64) (uint32, error) {
+	switch chainID {
+	case 1:
+		return validation.StandardConfigParamsMainnet.Proofs.Permissioned.GameType, nil
+	case 11155111:
+		return validation.StandardConfigParamsSepolia.Proofs.Permissioned.GameType, nil
+	default:
+		return 0, fmt.Errorf("unsupported chainID: %d", chainID)
+	}
+}
+
 func ScanOwnership(
 	ctx context.Context,
 	rpc *rpc.Client,
+	block *big.Int,
 	deployOutput DeployOPChainOutput,
 ) (L1OwnershipReport, error) {
 	w3Client := w3.NewClient(rpc)
@@ -141,6 +170,7 @@ func ScanOwnership(
 	if err := CallBatch(
 		ctx,
 		w3Client,
+		block,
 		batchCallMethod(deployOutput.OptimismPortalProxy, guardianFnABI, &report.Guardian),
 		batchCallMethod(deployOutput.PermissionedDisputeGame, challengerFnABI, &report.Challenger),
 		batchCallMethod(deployOutput.OpChainProxyAdmin, ownerFnABI, &report.ProxyAdminOwner),
@@ -154,6 +184,7 @@ func ScanOwnership(
 func ScanFDG(
 	ctx context.Context,
 	rpc *rpc.Client,
+	block *big.Int,
 	gameType uint32,
 	factoryAddr common.Address,
 	gameAddr common.Address,
@@ -168,6 +199,7 @@ func ScanFDG(
 	if err := CallBatch(
 		ctx,
 		w3Client,
+		block,
 		makeBatchCall(gameTypeABI, &report.GameType),
 		makeBatchCall(absolutePrestateFnABI, &report.AbsolutePrestate),
 		makeBatchCall(maxGameDepthABI, &maxGameDepth),
@@ -187,6 +219,7 @@ func ScanFDG(
 		if err := CallBatch(
 			ctx,
 			w3Client,
+			block,
 			batchCallMethod(factoryAddr, gameArgsABI, &gameArgs, gameType),
 		); err != nil {
 			return report, fmt.Errorf("failed to get FDG game args: %w", err)
@@ -218,6 +251,7 @@ func ScanFDG(
 func ScanSystemConfig(
 	ctx context.Context,
 	rpc *rpc.Client,
+	block *big.Int,
 	release string,
 	addr common.Address,
 ) (L1SystemConfigReport, error) {
@@ -297,6 +331,7 @@ func ScanSystemConfig(
 	if err := CallBatch(
 		ctx,
 		w3Client,
+		block,
 		calls...,
 	); err != nil {
 		return report, fmt.Errorf("failed to get system config data: %w", err)
@@ -305,9 +340,59 @@ func ScanSystemConfig(
 	return report, nil
 }
 
+// ScanGasPayingToken reads the ERC-20 metadata of a custom gas paying token and
+// the token the SystemConfig at systemConfigAddr reports. A SystemConfig that
+// cannot report a token is recorded in the report rather than returned as an
+// error, since newer releases removed custom gas token support.
+func ScanGasPayingToken(
+	ctx context.Context,
+	rpc *rpc.Client,
+	block *big.Int,
+	systemConfigAddr common.Address,
+	token common.Address,
+) (L1GasPayingTokenReport, error) {
+	w3Client := w3.NewClient(rpc)
+	makeTokenCall := bindBatchCallTo(token)
+	makeSystemConfigCall := bindBatchCallTo(systemConfigAddr)
+
+	report := L1GasPayingTokenReport{Token: token}
+	if err := CallBatch(
+		ctx,
+		w3Client,
+		block,
+		makeTokenCall(erc20NameABI, &report.Name),
+		makeTokenCall(erc20SymbolABI, &report.Symbol),
+		makeTokenCall(erc20DecimalsABI, &report.Decimals),
+	); err != nil {
+		return report, fmt.Errorf("failed to get gas paying token metadata: %w", err)
+	}
+
+	if err := CallBatch(
+		ctx,
+		w3Client,
+		block,
+		BatchCall{
+			To: systemConfigAddr,
+			Encoder: func() ([]byte, error) {
+				return gasPayingTokenABI.EncodeArgs()
+			},
+			Decoder: func(rawOutput []byte) error {
+				return gasPayingTokenABI.DecodeReturns(rawOutput, &report.SystemConfigToken, &report.SystemConfigTokenDecimals)
+			},
+		},
+		makeSystemConfigCall(gasPayingTokenNameABI, &report.SystemConfigTokenName),
+		makeSystemConfigCall(gasPayingTokenSymbolABI, &report.SystemConfigTokenSymbol),
+	); err != nil {
+		report.SystemConfigErr = err.Error()
+	}
+
+	return report, nil
+}
+
 func ScanSemvers(
 	ctx context.Context,
 	rpc *rpc.Client,
+	block *big.Int,
 	deployOutput DeployOPChainOutput,
 ) (L1SemversReport, error) {
 	w3Client := w3.NewClient(rpc)
@@ -317,6 +402,7 @@ func ScanSemvers(
 	if err := CallBatch(
 		ctx,
 		w3Client,
+		block,
 		makeBatchCall(deployOutput.SystemConfigProxy, &report.SystemConfig),
 		makeBatchCall(deployOutput.PermissionedDisputeGame, &report.PermissionedDisputeGame),
 		makeBatchCall(deployOutput.OptimismPortalProxy, &report.OptimismPortal),
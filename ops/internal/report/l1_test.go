@@ -84,8 +84,10 @@ func TestScanL1(t *testing.T) {
 		_, err := ScanL1(
 			ctx,
 			client,
+			nil,
 			deploymentTx,
 			release,
+			nil,
 		)
 		require.ErrorContains(t, err, expErr)
 		mock.AssertExpectations(t)
@@ -186,7 +188,7 @@ func TestScanSystemConfig(t *testing.T) {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
-			report, err := ScanSystemConfig(ctx, l1Client, tt.release, addr)
+			report, err := ScanSystemConfig(ctx, l1Client, nil, tt.release, addr)
 			require.NoError(t, err)
 			require.EqualValues(t, tt.report, report)
 			mockRPC.AssertExpectations(t)
@@ -194,6 +196,63 @@ func TestScanSystemConfig(t *testing.T) {
 	}
 }
 
+func TestScanGasPayingToken(t *testing.T) {
+	systemConfigAddr := common.HexToAddress("0x034edD2A225f7f429A63E0f1D2084B9E0A93b538")
+	token := common.HexToAddress("0xA2120b9e674d3fC3875f415A7DF52e382F141225")
+
+	t.Run("matching token", func(t *testing.T) {
+		t.Parallel()
+
+		l1Client, mockRPC := mockRPCClient(t, "test-scan-gas-paying-token.json")
+		report, err := ScanGasPayingToken(context.Background(), l1Client, nil, systemConfigAddr, token)
+		require.NoError(t, err)
+		require.Equal(t, L1GasPayingTokenReport{
+			Token:                     token,
+			Name:                      "Test Token",
+			Symbol:                    "TT",
+			Decimals:                  18,
+			SystemConfigToken:         token,
+			SystemConfigTokenName:     "Test Token",
+			SystemConfigTokenSymbol:   "TT",
+			SystemConfigTokenDecimals: 18,
+		}, report)
+		require.Empty(t, report.Mismatches())
+		mockRPC.AssertExpectations(t)
+	})
+
+	t.Run("SystemConfig without custom gas token support", func(t *testing.T) {
+		t.Parallel()
+
+		l1Client, mockRPC := mockRPCClient(t, "test-scan-gas-paying-token-unsupported.json")
+		report, err := ScanGasPayingToken(context.Background(), l1Client, nil, systemConfigAddr, token)
+		require.NoError(t, err)
+		require.Equal(t, "Test Token", report.Name)
+		require.NotEmpty(t, report.SystemConfigErr)
+		require.Len(t, report.Mismatches(), 1)
+		require.Contains(t, report.Mismatches()[0], "SystemConfig does not report a gas paying token")
+		mockRPC.AssertExpectations(t)
+	})
+}
+
+func TestL1GasPayingTokenReportMismatches(t *testing.T) {
+	token := common.HexToAddress("0xA2120b9e674d3fC3875f415A7DF52e382F141225")
+	report := L1GasPayingTokenReport{
+		Token:                     token,
+		Name:                      "Test Token",
+		Symbol:                    "TT",
+		Decimals:                  18,
+		SystemConfigToken:         common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"),
+		SystemConfigTokenName:     "Ether",
+		SystemConfigTokenSymbol:   "ETH",
+		SystemConfigTokenDecimals: 18,
+	}
+	require.Equal(t, []string{
+		"SystemConfig reports token 0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE, expected 0xA2120b9e674d3fC3875f415A7DF52e382F141225",
+		`SystemConfig reports token name "Ether", token has "Test Token"`,
+		`SystemConfig reports token symbol "ETH", token has "TT"`,
+	}, report.Mismatches())
+}
+
 func TestScanFDG(t *testing.T) {
 	factoryAddr := common.HexToAddress("0x05F9613aDB30026FFd634f38e5C4dFd30a197Fa1")
 	gameAddr := common.HexToAddress("0x034edD2A225f7f429A63E0f1D2084B9E0A93b538")
@@ -236,7 +295,7 @@ func TestScanFDG(t *testing.T) {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
-			report, err := ScanFDG(ctx, l1Client, tt.report.GameType, factoryAddr, gameAddr)
+			report, err := ScanFDG(ctx, l1Client, nil, tt.report.GameType, factoryAddr, gameAddr)
 			require.NoError(t, err)
 			require.EqualValues(t, tt.report, report)
 			mockRPC.AssertExpectations(t)
@@ -1,9 +1,11 @@
 package report
 
 import (
+	"fmt"
 	"math/big"
 	"time"
 
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/l1block"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -56,6 +58,44 @@ type L1SystemConfigReport struct {
 	GasPayingTokenSymbol   string
 }
 
+// L1GasPayingTokenReport compares a chain's custom gas paying token, as read
+// from the token's own ERC-20 metadata, with what its SystemConfig reports.
+// SystemConfigErr is set if the SystemConfig could not report a token, which is
+// the case for releases that removed custom gas token support.
+type L1GasPayingTokenReport struct {
+	Token                     common.Address
+	Name                      string
+	Symbol                    string
+	Decimals                  uint8
+	SystemConfigToken         common.Address
+	SystemConfigTokenName     string
+	SystemConfigTokenSymbol   string
+	SystemConfigTokenDecimals uint8
+	SystemConfigErr           string `json:",omitempty"`
+}
+
+// Mismatches describes every way the SystemConfig disagrees with the token.
+func (r L1GasPayingTokenReport) Mismatches() []string {
+	if r.SystemConfigErr != "" {
+		return []string{fmt.Sprintf("SystemConfig does not report a gas paying token: %s", r.SystemConfigErr)}
+	}
+
+	var out []string
+	if r.SystemConfigToken != r.Token {
+		out = append(out, fmt.Sprintf("SystemConfig reports token %s, expected %s", r.SystemConfigToken, r.Token))
+	}
+	if r.SystemConfigTokenName != r.Name {
+		out = append(out, fmt.Sprintf("SystemConfig reports token name %q, token has %q", r.SystemConfigTokenName, r.Name))
+	}
+	if r.SystemConfigTokenSymbol != r.Symbol {
+		out = append(out, fmt.Sprintf("SystemConfig reports token symbol %q, token has %q", r.SystemConfigTokenSymbol, r.Symbol))
+	}
+	if r.SystemConfigTokenDecimals != r.Decimals {
+		out = append(out, fmt.Sprintf("SystemConfig reports token decimals %d, token has %d", r.SystemConfigTokenDecimals, r.Decimals))
+	}
+	return out
+}
+
 type L1Report struct {
 	Release           string
 	DeploymentChainID uint64
@@ -64,6 +104,7 @@ type L1Report struct {
 	Ownership         L1OwnershipReport
 	Proofs            L1ProofsReport
 	SystemConfig      L1SystemConfigReport
+	GasPayingToken    *L1GasPayingTokenReport `json:",omitempty"`
 }
 
 type L2Report struct {
@@ -77,5 +118,6 @@ type Report struct {
 	L1Err       error
 	L2          *L2Report
 	L2Err       error
+	L1Block     *l1block.Ref
 	GeneratedAt time.Time
 }
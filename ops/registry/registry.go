@@ -0,0 +1,288 @@
+// Package registry provides read-only, typed access to the superchain registry.
+//
+// A Registry is loaded either from a checkout of the registry on disk or from
+// any fs.FS with the same layout, such as an embed.FS containing a snapshot of
+// the superchain directory. The types it returns are the same types the
+// registry's own tooling reads and writes, so they cannot drift from the
+// configs themselves.
+package registry
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/config"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/manage"
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/ethereum/go-ethereum/core"
+)
+
+type (
+	// Superchain is the name of a superchain, e.g. "mainnet" or "sepolia".
+	Superchain = config.Superchain
+
+	// Chain is a chain config from superchain/configs/<superchain>/<shortName>.toml.
+	Chain = config.Chain
+
+	// SuperchainDefinition is a superchain config from
+	// superchain/configs/<superchain>/superchain.toml.
+	SuperchainDefinition = config.SuperchainDefinition
+
+	// AddressesWithRoles are a chain's L1 contract addresses and roles from
+	// superchain/extra/addresses/addresses.json.
+	AddressesWithRoles = config.AddressesWithRoles
+
+	// ChainListEntry is a chain's entry in chainList.json.
+	ChainListEntry = config.ChainListEntry
+
+	// DependencyGraph is the interop dependency graph of the registry's chains.
+	DependencyGraph = manage.DependencyGraph
+
+	// DependencyNode is a chain in a DependencyGraph.
+	DependencyNode = manage.DependencyNode
+
+	// DependencyEdge is a dependency of one chain on another.
+	DependencyEdge = manage.DependencyEdge
+
+	// DependencyCluster is a set of chains connected by interop dependencies.
+	DependencyCluster = manage.DependencyCluster
+)
+
+// ErrNotFound is returned, wrapped, by every lookup that finds nothing.
+var ErrNotFound = errors.New("not found")
+
+// ChainConfig is a chain config along with where it lives in the registry.
+type ChainConfig struct {
+	Superchain Superchain
+	ShortName  string
+	Config     *Chain
+}
+
+// Registry is an immutable, in-memory view of the registry. Genesis files are
+// decompressed on demand; everything else is loaded up front.
+type Registry struct {
+	fsys        fs.FS
+	superchains map[Superchain]*SuperchainDefinition
+	chains      []ChainConfig
+	addresses   config.AddressesJSON
+	chainList   []ChainListEntry
+}
+
+// Load loads the registry from the repository checkout at dir.
+func Load(dir string) (*Registry, error) {
+	return LoadFS(os.DirFS(dir))
+}
+
+// LoadFS loads the registry from fsys, which must contain the superchain
+// directory at its root. chainList.json is optional, since snapshots usually
+// only contain the superchain directory.
+func LoadFS(fsys fs.FS) (*Registry, error) {
+	r := &Registry{
+		fsys:        fsys,
+		superchains: make(map[Superchain]*SuperchainDefinition),
+	}
+
+	configsDir := paths.SuperchainConfigsDir(".")
+	entries, err := fs.ReadDir(fsys, configsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", configsDir, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, paths.SuperchainConfig(".", entry.Name()))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read superchain config for %s: %w", entry.Name(), err)
+		}
+
+		def := new(SuperchainDefinition)
+		if err := toml.Unmarshal(data, def); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal superchain config for %s: %w", entry.Name(), err)
+		}
+		r.superchains[entry.Name()] = def
+	}
+
+	diskCfgs, err := manage.CollectChainConfigsFS(fsys, configsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect chain configs: %w", err)
+	}
+	for _, diskCfg := range diskCfgs {
+		r.chains = append(r.chains, ChainConfig{
+			Superchain: diskCfg.Superchain,
+			ShortName:  diskCfg.ShortName,
+			Config:     diskCfg.Config,
+		})
+	}
+	slices.SortFunc(r.chains, func(a, b ChainConfig) int {
+		return cmp.Compare(a.Config.ChainID, b.Config.ChainID)
+	})
+
+	if err := readJSON(fsys, paths.AddressesFile("."), &r.addresses); err != nil {
+		return nil, fmt.Errorf("failed to read addresses: %w", err)
+	}
+
+	err = readJSON(fsys, paths.ChainListJsonFile("."), &r.chainList)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read chain list: %w", err)
+	}
+
+	return r, nil
+}
+
+// Superchains returns the names of every superchain, sorted.
+func (r *Registry) Superchains() []Superchain {
+	out := make([]Superchain, 0, len(r.superchains))
+	for name := range r.superchains {
+		out = append(out, name)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// SuperchainDefinition returns the definition of the named superchain.
+func (r *Registry) SuperchainDefinition(superchain Superchain) (*SuperchainDefinition, error) {
+	def, ok := r.superchains[superchain]
+	if !ok {
+		return nil, fmt.Errorf("superchain %s: %w", superchain, ErrNotFound)
+	}
+	return def, nil
+}
+
+// Chains returns every chain in the registry, sorted by chain ID.
+func (r *Registry) Chains() []ChainConfig {
+	return slices.Clone(r.chains)
+}
+
+// ChainByID returns the chain with the given L2 chain ID.
+func (r *Registry) ChainByID(chainID uint64) (ChainConfig, error) {
+	for _, chain := range r.chains {
+		if chain.Config.ChainID == chainID {
+			return chain, nil
+		}
+	}
+	return ChainConfig{}, fmt.Errorf("chain %d: %w", chainID, ErrNotFound)
+}
+
+// ChainByShortName returns the chain with the given short name. Short names are
+// only unique within a superchain.
+func (r *Registry) ChainByShortName(superchain Superchain, shortName string) (ChainConfig, error) {
+	for _, chain := range r.chains {
+		if chain.Superchain == superchain && chain.ShortName == shortName {
+			return chain, nil
+		}
+	}
+	return ChainConfig{}, fmt.Errorf("chain %s/%s: %w", superchain, shortName, ErrNotFound)
+}
+
+// Addresses returns the L1 contract addresses and roles of the chain with the
+// given chain ID.
+func (r *Registry) Addresses(chainID uint64) (*AddressesWithRoles, error) {
+	addrs, ok := r.addresses[fmt.Sprintf("%d", chainID)]
+	if !ok || addrs == nil {
+		return nil, fmt.Errorf("addresses for chain %d: %w", chainID, ErrNotFound)
+	}
+	return addrs, nil
+}
+
+// ChainList returns every entry in chainList.json.
+func (r *Registry) ChainList() []ChainListEntry {
+	return slices.Clone(r.chainList)
+}
+
+// ChainListEntry returns the chainList.json entry of the chain with the given
+// chain ID.
+func (r *Registry) ChainListEntry(chainID uint64) (ChainListEntry, error) {
+	for _, entry := range r.chainList {
+		if entry.ChainID == chainID {
+			return entry, nil
+		}
+	}
+	return ChainListEntry{}, fmt.Errorf("chain list entry for chain %d: %w", chainID, ErrNotFound)
+}
+
+// Genesis decompresses and returns the genesis of the chain with the given
+// chain ID. Genesis files are large, so callers should cache the result if
+// they need it more than once.
+func (r *Registry) Genesis(chainID uint64) (*core.Genesis, error) {
+	chain, err := r.ChainByID(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	genPath := paths.GenesisFile(".", chain.Superchain, chain.ShortName)
+	genF, err := r.fsys.Open(genPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("genesis for chain %d: %w", chainID, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open genesis: %w", err)
+	}
+	defer genF.Close()
+
+	dict, err := fs.ReadFile(r.fsys, path.Join(paths.ExtraDir("."), "dictionary"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dictionary: %w", err)
+	}
+
+	return manage.DecompressGenesis(genF, dict)
+}
+
+// RollupConfig returns the op-node rollup config of the chain with the given
+// chain ID, as it would be written to rollup.json.
+func (r *Registry) RollupConfig(chainID uint64) (*rollup.Config, error) {
+	chain, err := r.ChainByID(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	superchain, err := r.SuperchainDefinition(chain.Superchain)
+	if err != nil {
+		return nil, err
+	}
+
+	return manage.RollupConfig(chain.Config, superchain)
+}
+
+// InteropGraph returns the interop dependency graph of every chain in the
+// registry.
+func (r *Registry) InteropGraph() (*DependencyGraph, error) {
+	cfgs := make([]manage.DiskChainConfig, 0, len(r.chains))
+	for _, chain := range r.chains {
+		cfgs = append(cfgs, manage.DiskChainConfig{
+			Superchain: chain.Superchain,
+			ShortName:  chain.ShortName,
+			Config:     chain.Config,
+		})
+	}
+	return manage.NewDependencyGraph(cfgs)
+}
+
+// InteropClusters returns the sets of chains connected by interop
+// dependencies, sorted by their lowest chain ID.
+func (r *Registry) InteropClusters() ([]DependencyCluster, error) {
+	g, err := r.InteropGraph()
+	if err != nil {
+		return nil, err
+	}
+	return g.Clusters(), nil
+}
+
+func readJSON(fsys fs.FS, p string, out any) error {
+	data, err := fs.ReadFile(fsys, p)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,108 @@
+package registry
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/ethereum-optimism/superchain-registry/ops/internal/paths"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	rootDir, err := paths.FindRepoRoot()
+	require.NoError(t, err)
+
+	r, err := Load(rootDir)
+	require.NoError(t, err)
+
+	require.Contains(t, r.Superchains(), "mainnet")
+	require.Contains(t, r.Superchains(), "sepolia")
+
+	def, err := r.SuperchainDefinition("mainnet")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), def.L1.ChainID)
+
+	chain, err := r.ChainByID(10)
+	require.NoError(t, err)
+	require.Equal(t, "mainnet", chain.Superchain)
+	require.Equal(t, "op", chain.ShortName)
+
+	chain, err = r.ChainByShortName("sepolia", "op")
+	require.NoError(t, err)
+	require.Equal(t, uint64(11155420), chain.Config.ChainID)
+
+	addrs, err := r.Addresses(10)
+	require.NoError(t, err)
+	require.NotNil(t, addrs.SystemConfigProxy)
+
+	entry, err := r.ChainListEntry(10)
+	require.NoError(t, err)
+	require.Equal(t, "mainnet/op", entry.Identifier)
+
+	chains := r.Chains()
+	require.Len(t, chains, len(r.ChainList()))
+	for i := 1; i < len(chains); i++ {
+		require.Less(t, chains[i-1].Config.ChainID, chains[i].Config.ChainID)
+	}
+
+	rollupCfg, err := r.RollupConfig(10)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, rollupCfg.L1ChainID.Uint64())
+	require.Equal(t, addrs.SystemConfigProxy.String(), rollupCfg.L1SystemConfigAddress.Hex())
+
+	zora, err := r.ChainByShortName("sepolia", "zora")
+	require.NoError(t, err)
+	genesis, err := r.Genesis(zora.Config.ChainID)
+	require.NoError(t, err)
+	require.Equal(t, zora.Config.ChainID, genesis.Config.ChainID.Uint64())
+}
+
+func TestNotFound(t *testing.T) {
+	r, err := LoadFS(fstest.MapFS{
+		"superchain/configs/sepolia/superchain.toml": {Data: []byte("name = \"Sepolia\"\n")},
+		"superchain/configs/sepolia/test.toml":       {Data: []byte("name = \"Test\"\nchain_id = 1234\n")},
+		"superchain/extra/addresses/addresses.json":  {Data: []byte("{}")},
+	})
+	require.NoError(t, err)
+
+	chain, err := r.ChainByShortName("sepolia", "test")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1234), chain.Config.ChainID)
+
+	_, err = r.SuperchainDefinition("mainnet")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = r.ChainByID(1)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = r.ChainByShortName("mainnet", "test")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = r.Addresses(1234)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = r.ChainListEntry(1234)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = r.Genesis(1234)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestInteropClusters(t *testing.T) {
+	r, err := LoadFS(fstest.MapFS{
+		"superchain/configs/sepolia/superchain.toml": {Data: []byte("name = \"Sepolia\"\n")},
+		"superchain/configs/sepolia/a.toml":          {Data: []byte("name = \"A\"\nchain_id = 901\n[interop]\ndependencies.\"901\" = {}\ndependencies.\"902\" = {}\n")},
+		"superchain/configs/sepolia/b.toml":          {Data: []byte("name = \"B\"\nchain_id = 902\n[interop]\ndependencies.\"901\" = {}\ndependencies.\"902\" = {}\n")},
+		"superchain/configs/sepolia/c.toml":          {Data: []byte("name = \"C\"\nchain_id = 903\n")},
+		"superchain/extra/addresses/addresses.json":  {Data: []byte("{}")},
+	})
+	require.NoError(t, err)
+
+	clusters, err := r.InteropClusters()
+	require.NoError(t, err)
+	require.Equal(t, []DependencyCluster{
+		{ChainIDs: []uint64{901, 902}, Complete: true},
+	}, clusters)
+
+	g, err := r.InteropGraph()
+	require.NoError(t, err)
+	node, ok := g.Node(901)
+	require.True(t, ok)
+	require.Equal(t, "a", node.ShortName)
+	require.Equal(t, "sepolia", node.Superchain)
+}
@@ -0,0 +1,130 @@
+package validation
+
+import (
+	_ "embed"
+	"fmt"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+)
+
+// GameType identifies a dispute game implementation, as registered with the
+// DisputeGameFactory. It is written as the name of the game type if it is in
+// StandardGameTypes, and can be read from either its name or its ID.
+type GameType uint32
+
+// Info returns the entry of the game type in StandardGameTypes.
+func (t GameType) Info() (GameTypeInfo, bool) {
+	return StandardGameTypes.ByID(t)
+}
+
+// Permissionless returns whether anyone can propose and challenge outputs with
+// games of this type. Unknown game types are treated as permissioned.
+func (t GameType) Permissionless() bool {
+	info, ok := t.Info()
+	return ok && info.Permissionless
+}
+
+func (t GameType) String() string {
+	if info, ok := t.Info(); ok {
+		return info.Name
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
+func (t GameType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *GameType) UnmarshalText(text []byte) error {
+	if info, ok := StandardGameTypes.ByName(string(text)); ok {
+		*t = info.ID
+		return nil
+	}
+	id, err := strconv.ParseUint(string(text), 10, 32)
+	if err != nil {
+		return fmt.Errorf("unknown game type %q", text)
+	}
+	*t = GameType(id)
+	return nil
+}
+
+// VMFamily is the instruction set of the fault proof VM a game type bisects
+// over.
+type VMFamily string
+
+const (
+	VMFamilyMIPS  VMFamily = "mips"
+	VMFamilyRISCV VMFamily = "riscv"
+)
+
+type GameTypeInfo struct {
+	ID             GameType `toml:"id"`
+	Name           string   `toml:"name"`
+	Permissionless bool     `toml:"permissionless"`
+	VMFamily       VMFamily `toml:"vm_family"`
+}
+
+type GameTypes struct {
+	GameTypes []GameTypeInfo `toml:"game_types"`
+}
+
+func (g GameTypes) ByID(id GameType) (GameTypeInfo, bool) {
+	for _, info := range g.GameTypes {
+		if info.ID == id {
+			return info, true
+		}
+	}
+	return GameTypeInfo{}, false
+}
+
+func (g GameTypes) ByName(name string) (GameTypeInfo, bool) {
+	for _, info := range g.GameTypes {
+		if info.Name == name {
+			return info, true
+		}
+	}
+	return GameTypeInfo{}, false
+}
+
+func (g GameTypes) validate() error {
+	ids := make(map[GameType]bool)
+	names := make(map[string]bool)
+	for _, info := range g.GameTypes {
+		if info.Name == "" {
+			return fmt.Errorf("game type %d has no name", info.ID)
+		}
+		if ids[info.ID] {
+			return fmt.Errorf("duplicate game type ID %d", info.ID)
+		}
+		if names[info.Name] {
+			return fmt.Errorf("duplicate game type name %s", info.Name)
+		}
+		switch info.VMFamily {
+		case VMFamilyMIPS, VMFamilyRISCV:
+		default:
+			return fmt.Errorf("game type %s has unknown VM family %q", info.Name, info.VMFamily)
+		}
+		ids[info.ID] = true
+		names[info.Name] = true
+	}
+	return nil
+}
+
+//go:embed standard/standard-game-types.toml
+var standardGameTypesBytes []byte
+
+// StandardGameTypes is loaded before the init functions of the package run,
+// since the standard params are validated against it.
+var StandardGameTypes = mustLoadGameTypes()
+
+func mustLoadGameTypes() GameTypes {
+	var gameTypes GameTypes
+	if err := toml.Unmarshal(standardGameTypesBytes, &gameTypes); err != nil {
+		panic(fmt.Errorf("failed to unmarshal standard game types: %w", err))
+	}
+	if err := gameTypes.validate(); err != nil {
+		panic(fmt.Errorf("invalid standard game types: %w", err))
+	}
+	return gameTypes
+}
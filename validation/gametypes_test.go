@@ -0,0 +1,95 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameType(t *testing.T) {
+	t.Run("known game types", func(t *testing.T) {
+		info, ok := GameType(1).Info()
+		require.True(t, ok)
+		require.Equal(t, GameTypeInfo{ID: 1, Name: "PERMISSIONED_CANNON", VMFamily: VMFamilyMIPS}, info)
+		require.False(t, GameType(1).Permissionless())
+		require.Equal(t, "PERMISSIONED_CANNON", GameType(1).String())
+
+		for _, id := range []GameType{0, 2, 3, 4, 7, 8, 9} {
+			require.True(t, id.Permissionless(), "game type %d", id)
+		}
+		info, ok = StandardGameTypes.ByName("ASTERISC_KONA")
+		require.True(t, ok)
+		require.Equal(t, VMFamilyRISCV, info.VMFamily)
+	})
+
+	t.Run("unknown game types", func(t *testing.T) {
+		_, ok := GameType(1337).Info()
+		require.False(t, ok)
+		require.False(t, GameType(1337).Permissionless())
+		require.Equal(t, "1337", GameType(1337).String())
+	})
+
+	t.Run("text round trip", func(t *testing.T) {
+		for _, id := range []GameType{0, 5, 1337} {
+			text, err := id.MarshalText()
+			require.NoError(t, err)
+			var got GameType
+			require.NoError(t, got.UnmarshalText(text))
+			require.Equal(t, id, got)
+		}
+
+		var got GameType
+		require.NoError(t, got.UnmarshalText([]byte("9")))
+		require.Equal(t, GameType(9), got)
+		require.ErrorContains(t, got.UnmarshalText([]byte("NOT_A_GAME")), "unknown game type")
+	})
+
+	t.Run("TOML names and IDs", func(t *testing.T) {
+		var params struct {
+			ByName GameType `toml:"by_name"`
+			ByID   GameType `toml:"by_id"`
+		}
+		require.NoError(t, toml.Unmarshal([]byte("by_name = \"SUPER_CANNON\"\nby_id = 8\n"), &params))
+		require.Equal(t, GameType(4), params.ByName)
+		require.Equal(t, GameType(8), params.ByID)
+	})
+}
+
+func TestGameTypesValidate(t *testing.T) {
+	require.NoError(t, StandardGameTypes.validate())
+
+	for _, tt := range []struct {
+		name      string
+		gameTypes []GameTypeInfo
+		err       string
+	}{
+		{"no name", []GameTypeInfo{{ID: 0, VMFamily: VMFamilyMIPS}}, "has no name"},
+		{"duplicate ID", []GameTypeInfo{{ID: 0, Name: "A", VMFamily: VMFamilyMIPS}, {ID: 0, Name: "B", VMFamily: VMFamilyMIPS}}, "duplicate game type ID"},
+		{"duplicate name", []GameTypeInfo{{ID: 0, Name: "A", VMFamily: VMFamilyMIPS}, {ID: 1, Name: "A", VMFamily: VMFamilyMIPS}}, "duplicate game type name"},
+		{"unknown VM family", []GameTypeInfo{{ID: 0, Name: "A", VMFamily: "wasm"}}, "unknown VM family"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorContains(t, GameTypes{GameTypes: tt.gameTypes}.validate(), tt.err)
+		})
+	}
+}
+
+func TestConfigParamsValidate(t *testing.T) {
+	require.NoError(t, StandardConfigParamsMainnet.Validate())
+	require.NoError(t, StandardConfigParamsSepolia.Validate())
+	require.Equal(t, 0, StandardConfigParamsMainnet.OptimismPortal2.RespectedGameType)
+	require.Equal(t, uint32(1), StandardConfigParamsMainnet.Proofs.Permissioned.GameType)
+
+	params := StandardConfigParamsMainnet
+	params.OptimismPortal2.RespectedGameType = 1337
+	require.ErrorContains(t, params.Validate(), "unknown respected game type 1337")
+
+	params = StandardConfigParamsMainnet
+	params.Proofs.Permissioned.GameType = 0
+	require.ErrorContains(t, params.Validate(), "permissioned game type CANNON is not permissioned")
+
+	params = StandardConfigParamsMainnet
+	params.Proofs.Permissionless.GameType = 5
+	require.ErrorContains(t, params.Validate(), "permissionless game type SUPER_PERMISSIONED_CANNON is not permissionless")
+}
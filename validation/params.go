@@ -79,6 +79,31 @@ type ConfigParams struct {
 	Proofs          ProofsParams          `toml:"proofs"`
 }
 
+// Validate checks that the game types of p are in StandardGameTypes, and that
+// the permissioned and permissionless games are of the right kind.
+func (p ConfigParams) Validate() error {
+	respected := GameType(p.OptimismPortal2.RespectedGameType)
+	if _, ok := respected.Info(); !ok {
+		return fmt.Errorf("unknown respected game type %s", respected)
+	}
+	for _, proofs := range []struct {
+		name           string
+		gameType       GameType
+		permissionless bool
+	}{
+		{"permissioned", GameType(p.Proofs.Permissioned.GameType), false},
+		{"permissionless", GameType(p.Proofs.Permissionless.GameType), true},
+	} {
+		if _, ok := proofs.gameType.Info(); !ok {
+			return fmt.Errorf("unknown %s game type %s", proofs.name, proofs.gameType)
+		}
+		if proofs.gameType.Permissionless() != proofs.permissionless {
+			return fmt.Errorf("%s game type %s is not %s", proofs.name, proofs.gameType, proofs.name)
+		}
+	}
+	return nil
+}
+
 //go:embed standard/standard-config-params-mainnet.toml
 var standardConfigParamsMainnetToml []byte
 
@@ -94,7 +119,13 @@ func init() {
 	if err := toml.Unmarshal(standardConfigParamsMainnetToml, &StandardConfigParamsMainnet); err != nil {
 		panic(fmt.Errorf("failed to unmarshal mainnet standard config params: %w", err))
 	}
+	if err := StandardConfigParamsMainnet.Validate(); err != nil {
+		panic(fmt.Errorf("invalid mainnet standard config params: %w", err))
+	}
 	if err := toml.Unmarshal(standardConfigParamsSepoliaToml, &StandardConfigParamsSepolia); err != nil {
 		panic(fmt.Errorf("failed to unmarshal sepoliastandard config params: %w", err))
 	}
+	if err := StandardConfigParamsSepolia.Validate(); err != nil {
+		panic(fmt.Errorf("invalid sepolia standard config params: %w", err))
+	}
 }